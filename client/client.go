@@ -1,20 +1,26 @@
-// client is used internally for testing. See readme for alternatives
+// client sends graphql requests over http and websockets, see the readme for the available options
 package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
 
 // Client for graphql requests
 type Client struct {
-	url    string
-	client *http.Client
+	url     string
+	client  *http.Client
+	options []Option
 }
 
 // New creates a graphql client
@@ -31,10 +37,26 @@ func New(url string, client ...*http.Client) *Client {
 	return p
 }
 
+// WithOptions returns a copy of the client that applies the options to every request, before the options given to
+// the request itself.
+func (p *Client) WithOptions(options ...Option) *Client {
+	cpy := *p
+	cpy.options = append(append([]Option{}, p.options...), options...)
+	return &cpy
+}
+
 type Request struct {
-	Query         string                 `json:"query"`
+	Query         string                 `json:"query,omitempty"`
 	Variables     map[string]interface{} `json:"variables,omitempty"`
 	OperationName string                 `json:"operationName,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+
+	Header http.Header `json:"-"` // Sent with the http request
+	Method string      `json:"-"` // POST unless the request is sent with UseGET
+
+	persisted bool
+	retries   int
+	backoff   time.Duration
 }
 
 type Option func(r *Request)
@@ -55,6 +77,42 @@ func Operation(name string) Option {
 	}
 }
 
+// Header sets a http header on the request, eg for authentication.
+func Header(key string, value string) Option {
+	return func(r *Request) {
+		if r.Header == nil {
+			r.Header = http.Header{}
+		}
+		r.Header.Set(key, value)
+	}
+}
+
+// UseGET sends the request with its parameters in the url, so caches between the client and server can store
+// the response. Servers only accept queries this way, not mutations.
+func UseGET() Option {
+	return func(r *Request) {
+		r.Method = http.MethodGet
+	}
+}
+
+// PersistedQuery sends the sha256 hash of the query instead of the query itself. If the server has not seen the
+// query yet it is sent again in full, so the server can store it for next time.
+func PersistedQuery() Option {
+	return func(r *Request) {
+		r.persisted = true
+	}
+}
+
+// Retry resends a request up to attempts more times when it fails before reaching the server, or the server
+// responds with 502, 503 or 504. It waits for backoff before the first retry, doubling the wait every time.
+// Only retry mutations that are safe to run twice.
+func Retry(attempts int, backoff time.Duration) Option {
+	return func(r *Request) {
+		r.retries = attempts
+		r.backoff = backoff
+	}
+}
+
 func (p *Client) MustPost(query string, response interface{}, options ...Option) {
 	if err := p.Post(query, response, options...); err != nil {
 		panic(err)
@@ -63,9 +121,13 @@ func (p *Client) MustPost(query string, response interface{}, options ...Option)
 
 func (p *Client) mkRequest(query string, options ...Option) Request {
 	r := Request{
-		Query: query,
+		Query:  query,
+		Method: http.MethodPost,
 	}
 
+	for _, option := range p.options {
+		option(&r)
+	}
 	for _, option := range options {
 		option(&r)
 	}
@@ -74,46 +136,234 @@ func (p *Client) mkRequest(query string, options ...Option) Request {
 }
 
 func (p *Client) Post(query string, response interface{}, options ...Option) error {
+	respDataRaw, err := p.send(context.Background(), query, options...)
+	if err != nil {
+		return err
+	}
+
+	if respDataRaw.status >= http.StatusBadRequest {
+		return &HTTPError{StatusCode: respDataRaw.status, Body: respDataRaw.body}
+	}
+
+	if respDataRaw.Errors != nil {
+		return RawJsonError{respDataRaw.Errors}
+	}
+
+	// decode it into map string first, let mapstructure do the final decode
+	// because it can be much stricter about unknown fields.
+	var data interface{}
+	if err = json.Unmarshal(respDataRaw.Data, &data); err != nil {
+		return fmt.Errorf("decode: %s", err.Error())
+	}
+
+	return unpack(data, response)
+}
+
+// Do sends the query and decodes the data into response with encoding/json, so custom unmarshalers are used and
+// unknown fields are ignored. Graphql errors are returned as Errors, after any partial data has been decoded into
+// response.
+func (p *Client) Do(ctx context.Context, query string, response interface{}, options ...Option) error {
+	respDataRaw, err := p.send(ctx, query, options...)
+	if err != nil {
+		return err
+	}
+
+	errs, err := respDataRaw.errors()
+	if err != nil {
+		return err
+	}
+
+	if len(errs) == 0 && respDataRaw.status >= http.StatusBadRequest {
+		return &HTTPError{StatusCode: respDataRaw.status, Body: respDataRaw.body}
+	}
+
+	if len(respDataRaw.Data) > 0 {
+		if err = json.Unmarshal(respDataRaw.Data, response); err != nil {
+			return fmt.Errorf("decode: %s", err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+type rawResponse struct {
+	Data   json.RawMessage
+	Errors json.RawMessage
+
+	status int
+	body   []byte
+}
+
+func (r *rawResponse) errors() (Errors, error) {
+	if r.Errors == nil {
+		return nil, nil
+	}
+	var errs Errors
+	if err := json.Unmarshal(r.Errors, &errs); err != nil {
+		return nil, fmt.Errorf("decode: %s", err.Error())
+	}
+	return errs, nil
+}
+
+// persistedQueryMissing is true when the server could not use the hash of a persisted query
+func (r *rawResponse) persistedQueryMissing() bool {
+	errs, err := r.errors()
+	if err != nil || len(errs) != 1 {
+		return false
+	}
+	return errs[0].Message == "PersistedQueryNotFound" || errs[0].Message == "PersistedQueryNotSupported"
+}
+
+func (p *Client) send(ctx context.Context, query string, options ...Option) (*rawResponse, error) {
 	r := p.mkRequest(query, options...)
-	requestBody, err := json.Marshal(r)
+	if !r.persisted {
+		return p.sendWithRetries(ctx, r)
+	}
+
+	hash := sha256.Sum256([]byte(r.Query))
+	extensions := map[string]interface{}{}
+	for k, v := range r.Extensions {
+		extensions[k] = v
+	}
+	extensions["persistedQuery"] = map[string]interface{}{
+		"version":    1,
+		"sha256Hash": hex.EncodeToString(hash[:]),
+	}
+
+	hashOnly := r
+	hashOnly.Query = ""
+	hashOnly.Extensions = extensions
+	respDataRaw, err := p.sendWithRetries(ctx, hashOnly)
+	if err != nil || !respDataRaw.persistedQueryMissing() {
+		return respDataRaw, err
+	}
+
+	// the server needs the whole query once, send the hash along with it so it gets stored
+	r.Extensions = extensions
+	return p.sendWithRetries(ctx, r)
+}
+
+func (p *Client) sendWithRetries(ctx context.Context, r Request) (*rawResponse, error) {
+	backoff := r.backoff
+	for attempt := 0; ; attempt++ {
+		respDataRaw, err := p.roundTrip(ctx, r)
+		if attempt >= r.retries || ctx.Err() != nil || !retryable(respDataRaw, err) {
+			return respDataRaw, err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("post: %s", ctx.Err().Error())
+		}
+		backoff *= 2
+	}
+}
+
+func retryable(respDataRaw *rawResponse, err error) bool {
 	if err != nil {
-		return fmt.Errorf("encode: %s", err.Error())
+		_, isTransportErr := err.(transportError)
+		return isTransportErr
+	}
+	switch respDataRaw.status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
 	}
+	return false
+}
+
+// transportError is returned when the request never got a response from the server
+type transportError struct {
+	error
+}
 
-	rawResponse, err := p.client.Post(p.url, "application/json", bytes.NewBuffer(requestBody))
+// roundTrip sends a single request. The body of error responses is decoded too, so that any graphql errors in it
+// can be returned.
+func (p *Client) roundTrip(ctx context.Context, r Request) (*rawResponse, error) {
+	req, err := p.httpRequest(r)
 	if err != nil {
-		return fmt.Errorf("post: %s", err.Error())
+		return nil, err
 	}
-	defer func() {
-		_ = rawResponse.Body.Close()
-	}()
 
-	if rawResponse.StatusCode >= http.StatusBadRequest {
-		responseBody, _ := ioutil.ReadAll(rawResponse.Body)
-		return fmt.Errorf("http %d: %s", rawResponse.StatusCode, responseBody)
+	httpResponse, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, transportError{fmt.Errorf("post: %s", err.Error())}
 	}
+	defer func() {
+		_ = httpResponse.Body.Close()
+	}()
 
-	responseBody, err := ioutil.ReadAll(rawResponse.Body)
+	responseBody, err := ioutil.ReadAll(httpResponse.Body)
 	if err != nil {
-		return fmt.Errorf("read: %s", err.Error())
+		return nil, fmt.Errorf("read: %s", err.Error())
 	}
 
-	// decode it into map string first, let mapstructure do the final decode
-	// because it can be much stricter about unknown fields.
-	respDataRaw := struct {
-		Data   interface{}
-		Errors json.RawMessage
-	}{}
+	respDataRaw := rawResponse{status: httpResponse.StatusCode, body: responseBody}
 	err = json.Unmarshal(responseBody, &respDataRaw)
-	if err != nil {
-		return fmt.Errorf("decode: %s", err.Error())
+	if err != nil && httpResponse.StatusCode < http.StatusBadRequest {
+		return nil, fmt.Errorf("decode: %s", err.Error())
 	}
 
-	if respDataRaw.Errors != nil {
-		return RawJsonError{respDataRaw.Errors}
+	return &respDataRaw, nil
+}
+
+func (p *Client) httpRequest(r Request) (*http.Request, error) {
+	var req *http.Request
+	if r.Method == http.MethodGet {
+		u, err := url.Parse(p.url)
+		if err != nil {
+			return nil, fmt.Errorf("url: %s", err.Error())
+		}
+
+		params := u.Query()
+		if r.Query != "" {
+			params.Set("query", r.Query)
+		}
+		if r.OperationName != "" {
+			params.Set("operationName", r.OperationName)
+		}
+		if err := setJSONParam(params, "variables", r.Variables); err != nil {
+			return nil, err
+		}
+		if err := setJSONParam(params, "extensions", r.Extensions); err != nil {
+			return nil, err
+		}
+		u.RawQuery = params.Encode()
+
+		if req, err = http.NewRequest(http.MethodGet, u.String(), nil); err != nil {
+			return nil, fmt.Errorf("request: %s", err.Error())
+		}
+	} else {
+		requestBody, err := json.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("encode: %s", err.Error())
+		}
+
+		if req, err = http.NewRequest(r.Method, p.url, bytes.NewBuffer(requestBody)); err != nil {
+			return nil, fmt.Errorf("request: %s", err.Error())
+		}
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return unpack(respDataRaw.Data, response)
+	for key, values := range r.Header {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+func setJSONParam(params url.Values, name string, value map[string]interface{}) error {
+	if len(value) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encode: %s", err.Error())
+	}
+	params.Set(name, string(b))
+	return nil
 }
 
 type RawJsonError struct {
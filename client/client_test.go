@@ -1,11 +1,15 @@
 package client_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/vektah/gqlgen/client"
@@ -39,3 +43,146 @@ func TestClient(t *testing.T) {
 
 	require.Equal(t, "bob", resp.Name)
 }
+
+func TestDo(t *testing.T) {
+	type user struct {
+		Name string
+	}
+
+	t.Run("headers and default options", func(t *testing.T) {
+		h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.Equal(t, "abc", r.Header.Get("X-Request-Id"))
+			writeJSON(w, `{"data":{"name":"bob","age":20}}`)
+		}))
+		defer h.Close()
+
+		c := client.New(h.URL).WithOptions(client.Header("Authorization", "Bearer token"))
+
+		var resp user
+		err := c.Do(context.Background(), "{ name }", &resp, client.Header("X-Request-Id", "abc"))
+		require.NoError(t, err)
+		require.Equal(t, "bob", resp.Name)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "query User($id: ID!) { user(id: $id) { name } }", r.URL.Query().Get("query"))
+			require.Equal(t, "User", r.URL.Query().Get("operationName"))
+			require.Equal(t, `{"id":1}`, r.URL.Query().Get("variables"))
+			writeJSON(w, `{"data":{"name":"bob"}}`)
+		}))
+		defer h.Close()
+
+		var resp user
+		err := client.New(h.URL).Do(context.Background(), "query User($id: ID!) { user(id: $id) { name } }", &resp,
+			client.UseGET(), client.Operation("User"), client.Var("id", 1))
+		require.NoError(t, err)
+		require.Equal(t, "bob", resp.Name)
+	})
+
+	t.Run("persisted queries", func(t *testing.T) {
+		var requests []map[string]interface{}
+		h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			requests = append(requests, req)
+
+			if req["query"] == nil {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				writeJSON(w, `{"errors":[{"message":"PersistedQueryNotFound"}]}`)
+				return
+			}
+			writeJSON(w, `{"data":{"name":"bob"}}`)
+		}))
+		defer h.Close()
+
+		var resp user
+		err := client.New(h.URL).Do(context.Background(), "{ name }", &resp, client.PersistedQuery())
+		require.NoError(t, err)
+		require.Equal(t, "bob", resp.Name)
+
+		sum := sha256.Sum256([]byte("{ name }"))
+		hash := hex.EncodeToString(sum[:])
+		persisted := map[string]interface{}{"persistedQuery": map[string]interface{}{"version": float64(1), "sha256Hash": hash}}
+
+		require.Len(t, requests, 2)
+		require.Equal(t, map[string]interface{}{"extensions": persisted}, requests[0])
+		require.Equal(t, map[string]interface{}{"query": "{ name }", "extensions": persisted}, requests[1])
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		attempts := 0
+		h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			writeJSON(w, `{"data":{"name":"bob"}}`)
+		}))
+		defer h.Close()
+
+		var resp user
+		err := client.New(h.URL).Do(context.Background(), "{ name }", &resp, client.Retry(1, time.Millisecond))
+		require.EqualError(t, err, "http 503: ")
+		require.Equal(t, 503, err.(*client.HTTPError).StatusCode)
+
+		attempts = 0
+		err = client.New(h.URL).Do(context.Background(), "{ name }", &resp, client.Retry(2, time.Millisecond))
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+		require.Equal(t, "bob", resp.Name)
+
+		t.Run("not once the server has responded", func(t *testing.T) {
+			attempts := 0
+			h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.Header().Set("Content-Length", "100")
+				writeJSON(w, `{"data":`)
+			}))
+			defer h.Close()
+
+			err := client.New(h.URL).Do(context.Background(), "{ name }", &resp, client.Retry(2, time.Millisecond))
+			require.EqualError(t, err, "read: unexpected EOF")
+			require.Equal(t, 1, attempts)
+		})
+	})
+
+	t.Run("errors with partial data", func(t *testing.T) {
+		h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, `{"data":{"name":"bob"},"errors":[{"message":"no age","path":["age"]},{"message":"no email"}]}`)
+		}))
+		defer h.Close()
+
+		var resp user
+		err := client.New(h.URL).Do(context.Background(), "{ name age email }", &resp)
+		require.EqualError(t, err, "no age; no email")
+		require.Equal(t, "bob", resp.Name)
+
+		errs := err.(client.Errors)
+		require.Len(t, errs, 2)
+		require.Equal(t, []interface{}{"age"}, errs[0].Path)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		h := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer h.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		var resp user
+		err := client.New(h.URL).Do(ctx, "{ name }", &resp, client.Retry(10, time.Hour))
+		require.EqualError(t, err, "post: context deadline exceeded")
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body string) {
+	if _, err := w.Write([]byte(body)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+// Errors are the graphql errors returned by the server. Any data that could still be resolved has been decoded
+// into the response when they are returned.
+type Errors []*graphql.Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// HTTPError is returned when the server responds with an error status and no graphql errors.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("http %d: %s", e.StatusCode, string(e.Body))
+}
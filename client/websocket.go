@@ -1,19 +1,31 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/graphql"
 )
 
 const (
-	connectionInitMsg = "connection_init" // Client -> Server
-	startMsg          = "start"           // Client -> Server
-	connectionAckMsg  = "connection_ack"  // Server -> Client
-	dataMsg           = "data"            // Server -> Client
+	connectionInitMsg      = "connection_init"      // Client -> Server
+	connectionTerminateMsg = "connection_terminate" // Client -> Server
+	startMsg               = "start"                // Client -> Server
+	stopMsg                = "stop"                 // Client -> Server
+	connectionAckMsg       = "connection_ack"       // Server -> Client
+	connectionErrorMsg     = "connection_error"     // Server -> Client
+	dataMsg                = "data"                 // Server -> Client
+	errorMsg               = "error"                // Server -> Client
+	completeMsg            = "complete"             // Server -> Client
+	connectionKeepAliveMsg = "ka"                   // Server -> Client
 )
 
 type operationMessage struct {
@@ -22,77 +34,469 @@ type operationMessage struct {
 	Type    string          `json:"type"`
 }
 
-type Subscription struct {
-	Close func() error
-	Next  func(response interface{}) error
+type websocketConfig struct {
+	initPayload      map[string]interface{}
+	reconnects       int
+	backoff          time.Duration
+	keepAliveTimeout time.Duration
 }
 
-func errorSubscription(err error) *Subscription {
-	return &Subscription{
-		Close: func() error { return nil },
-		Next: func(response interface{}) error {
-			return err
-		},
+type WebsocketOption func(cfg *websocketConfig)
+
+// WebsocketInitPayload is sent to the server in connection_init, commonly used for credentials as browsers cannot
+// set headers on websockets.
+func WebsocketInitPayload(payload map[string]interface{}) WebsocketOption {
+	return func(cfg *websocketConfig) {
+		cfg.initPayload = payload
 	}
 }
 
-func (p *Client) Websocket(query string, options ...Option) *Subscription {
-	r := p.mkRequest(query, options...)
-	requestBody, err := json.Marshal(r)
+// WebsocketReconnect redials up to attempts times when the connection is lost, waiting for backoff before the first
+// attempt and doubling it every time. Running subscriptions are started again on the new connection, events sent
+// while disconnected are lost.
+func WebsocketReconnect(attempts int, backoff time.Duration) WebsocketOption {
+	return func(cfg *websocketConfig) {
+		cfg.reconnects = attempts
+		cfg.backoff = backoff
+	}
+}
+
+// WebsocketKeepAliveTimeout treats the connection as lost when nothing, not even a ka message, has been received for
+// the timeout. Only use it with servers that send keepalives more often than that.
+func WebsocketKeepAliveTimeout(timeout time.Duration) WebsocketOption {
+	return func(cfg *websocketConfig) {
+		cfg.keepAliveTimeout = timeout
+	}
+}
+
+// Message is a single result of a subscription.
+type Message struct {
+	Data   json.RawMessage
+	Errors Errors // Errors resolving this result, Data may still hold the fields that did resolve
+
+	// Err is set on the last message of a subscription that failed, either because the server rejected it or the
+	// connection was lost.
+	Err error
+}
+
+// Decode decodes the data of the message into response with encoding/json.
+func (m Message) Decode(response interface{}) error {
+	if len(m.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(m.Data, response); err != nil {
+		return fmt.Errorf("decode: %s", err.Error())
+	}
+	return nil
+}
+
+// WebsocketConn runs any number of subscriptions over a single graphql-ws connection.
+type WebsocketConn struct {
+	client *Client
+	cfg    websocketConfig
+
+	ctx      context.Context // cancelled by Close
+	cancel   context.CancelFunc
+	finished chan struct{}
+
+	mu     sync.Mutex // guards everything below, and writes to ws
+	ws     *websocket.Conn
+	subs   map[string]*wsSubscription
+	nextID int
+	closed bool
+}
+
+type wsSubscription struct {
+	id      string
+	payload json.RawMessage
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	mu       sync.Mutex
+	messages chan Message
+	closed   bool
+}
+
+// DialWebsocket connects to the server, the context only applies to connecting. Headers set with WithOptions are
+// sent with the upgrade request.
+func (p *Client) DialWebsocket(ctx context.Context, options ...WebsocketOption) (*WebsocketConn, error) {
+	c := &WebsocketConn{
+		client:   p,
+		subs:     map[string]*wsSubscription{},
+		finished: make(chan struct{}),
+	}
+	for _, option := range options {
+		option(&c.cfg)
+	}
+
+	ws, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.ws = ws
+	go c.run(ws)
+
+	return c, nil
+}
+
+// Subscribe starts an operation on the connection. Its results are sent on the returned channel, which is closed
+// when the server completes the operation, ctx is cancelled or the connection is closed. Results must be read
+// promptly: a subscription that is not being read holds up every other subscription on the connection.
+func (c *WebsocketConn) Subscribe(ctx context.Context, query string, options ...Option) <-chan Message {
+	sub := &wsSubscription{messages: make(chan Message, 1)}
+	sub.ctx, sub.cancel = context.WithCancel(ctx)
+
+	payload, err := json.Marshal(c.client.mkRequest(query, options...))
 	if err != nil {
-		return errorSubscription(fmt.Errorf("encode: %s", err.Error()))
+		sub.messages <- Message{Err: fmt.Errorf("encode: %s", err.Error())}
+		sub.close()
+		return sub.messages
+	}
+	sub.payload = payload
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		sub.messages <- Message{Err: fmt.Errorf("websocket: connection closed")}
+		sub.close()
+		return sub.messages
+	}
+	c.nextID++
+	sub.id = strconv.Itoa(c.nextID)
+	c.subs[sub.id] = sub
+	if c.ws != nil {
+		// if this fails the connection is gone, and the subscription is started again once it has been reestablished
+		_ = c.ws.WriteJSON(operationMessage{Type: startMsg, ID: sub.id, Payload: sub.payload})
+	}
+	c.mu.Unlock()
+
+	go func() {
+		<-sub.ctx.Done()
+		if c.remove(sub.id) != nil {
+			c.write(operationMessage{Type: stopMsg, ID: sub.id})
+		}
+		sub.close()
+	}()
+
+	return sub.messages
+}
+
+// Close stops every subscription and closes the connection.
+func (c *WebsocketConn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	var err error
+	if c.ws != nil {
+		_ = c.ws.WriteJSON(operationMessage{Type: connectionTerminateMsg})
+		err = c.ws.Close()
 	}
+	c.mu.Unlock()
+
+	c.cancel()
+	<-c.finished
+	return err
+}
 
-	url := strings.Replace(p.url, "http://", "ws://", -1)
+func (c *WebsocketConn) connect(ctx context.Context) (*websocket.Conn, error) {
+	url := strings.Replace(c.client.url, "http://", "ws://", -1)
 	url = strings.Replace(url, "https://", "wss://", -1)
 
-	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	// gorilla has no way to pass a context when dialing, so close the connection if ctx is done before it is ready
+	ready := make(chan struct{})
+	defer close(ready)
+	dialer := websocket.Dialer{
+		Proxy:        http.ProxyFromEnvironment,
+		Subprotocols: []string{"graphql-ws"},
+		NetDial: func(network, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+			if err == nil {
+				go func() {
+					select {
+					case <-ctx.Done():
+						_ = conn.Close()
+					case <-ready:
+					}
+				}()
+			}
+			return conn, err
+		},
+	}
+
+	ws, _, err := dialer.Dial(url, c.client.mkRequest("").Header)
 	if err != nil {
-		return errorSubscription(fmt.Errorf("dial: %s", err.Error()))
+		return nil, contextError(ctx, fmt.Errorf("dial: %s", err.Error()))
 	}
 
-	if err = c.WriteJSON(operationMessage{Type: connectionInitMsg}); err != nil {
-		return errorSubscription(fmt.Errorf("init: %s", err.Error()))
+	initMsg := operationMessage{Type: connectionInitMsg}
+	if c.cfg.initPayload != nil {
+		if initMsg.Payload, err = json.Marshal(c.cfg.initPayload); err != nil {
+			_ = ws.Close()
+			return nil, fmt.Errorf("encode: %s", err.Error())
+		}
+	}
+	if err = ws.WriteJSON(initMsg); err != nil {
+		_ = ws.Close()
+		return nil, contextError(ctx, fmt.Errorf("init: %s", err.Error()))
 	}
 
 	var ack operationMessage
-	if err = c.ReadJSON(&ack); err != nil {
-		return errorSubscription(fmt.Errorf("ack: %s", err.Error()))
+	if err = ws.ReadJSON(&ack); err != nil {
+		_ = ws.Close()
+		return nil, contextError(ctx, fmt.Errorf("ack: %s", err.Error()))
 	}
-	if ack.Type != connectionAckMsg {
-		return errorSubscription(fmt.Errorf("expected ack message, got %#v", ack))
+
+	switch ack.Type {
+	case connectionAckMsg:
+		return ws, nil
+	case connectionErrorMsg:
+		_ = ws.Close()
+		var connErr graphql.Error
+		if err = json.Unmarshal(ack.Payload, &connErr); err != nil {
+			return nil, fmt.Errorf("decode: %s", err.Error())
+		}
+		return nil, fmt.Errorf("init rejected: %s", connErr.Message)
+	default:
+		_ = ws.Close()
+		return nil, fmt.Errorf("expected ack message, got %#v", ack)
 	}
+}
 
-	if err = c.WriteJSON(operationMessage{Type: startMsg, ID: "1", Payload: requestBody}); err != nil {
-		return errorSubscription(fmt.Errorf("start: %s", err.Error()))
+func contextError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
+	return err
+}
 
-	return &Subscription{
-		Close: c.Close,
-		Next: func(response interface{}) error {
-			var op operationMessage
-			c.ReadJSON(&op)
-			if op.Type != dataMsg {
-				return fmt.Errorf("expected data message, got %#v", op)
+// run reads from the connection until it is closed, reconnecting whenever it is lost.
+func (c *WebsocketConn) run(ws *websocket.Conn) {
+	defer close(c.finished)
+
+	for {
+		err := c.read(ws)
+
+		c.mu.Lock()
+		c.ws = nil
+		closed := c.closed
+		c.mu.Unlock()
+		_ = ws.Close()
+		if closed {
+			c.shutdown(nil)
+			return
+		}
+
+		if ws, err = c.reconnect(err); err != nil {
+			c.shutdown(err)
+			return
+		}
+	}
+}
+
+func (c *WebsocketConn) reconnect(cause error) (*websocket.Conn, error) {
+	backoff := c.cfg.backoff
+	for attempt := 0; attempt < c.cfg.reconnects; attempt++ {
+		select {
+		case <-time.After(backoff):
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		}
+		backoff *= 2
+
+		ws, err := c.connect(c.ctx)
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			_ = ws.Close()
+			return nil, c.ctx.Err()
+		}
+		c.ws = ws
+		for _, sub := range c.subs {
+			_ = ws.WriteJSON(operationMessage{Type: startMsg, ID: sub.id, Payload: sub.payload})
+		}
+		c.mu.Unlock()
+		return ws, nil
+	}
+
+	return nil, cause
+}
+
+// read handles messages until the connection fails
+func (c *WebsocketConn) read(ws *websocket.Conn) error {
+	for {
+		if c.cfg.keepAliveTimeout > 0 {
+			if err := ws.SetReadDeadline(time.Now().Add(c.cfg.keepAliveTimeout)); err != nil {
+				return fmt.Errorf("read: %s", err.Error())
 			}
+		}
 
-			respDataRaw := map[string]interface{}{}
-			err = json.Unmarshal(op.Payload, &respDataRaw)
-			if err != nil {
+		var op operationMessage
+		if err := ws.ReadJSON(&op); err != nil {
+			return fmt.Errorf("read: %s", err.Error())
+		}
+
+		switch op.Type {
+		case dataMsg:
+			var msg Message
+			var payload rawResponse
+			if err := json.Unmarshal(op.Payload, &payload); err != nil {
+				msg.Err = fmt.Errorf("decode: %s", err.Error())
+			} else {
+				msg.Data = payload.Data
+				msg.Errors, msg.Err = payload.errors()
+			}
+			if sub := c.get(op.ID); sub != nil {
+				sub.send(c.ctx, msg)
+			}
+		case errorMsg:
+			var errs Errors
+			if err := json.Unmarshal(op.Payload, &errs); err != nil {
+				c.finish(op.ID, fmt.Errorf("decode: %s", err.Error()))
+			} else {
+				c.finish(op.ID, errs)
+			}
+		case completeMsg:
+			c.finish(op.ID, nil)
+		case connectionErrorMsg:
+			var connErr graphql.Error
+			if err := json.Unmarshal(op.Payload, &connErr); err != nil {
 				return fmt.Errorf("decode: %s", err.Error())
 			}
+			return fmt.Errorf("connection error: %s", connErr.Message)
+		case connectionKeepAliveMsg:
+		}
+	}
+}
+
+func (c *WebsocketConn) write(msg operationMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ws != nil {
+		_ = c.ws.WriteJSON(msg)
+	}
+}
+
+func (c *WebsocketConn) get(id string) *wsSubscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.subs[id]
+}
+
+// remove returns the subscription if it was still running
+func (c *WebsocketConn) remove(id string) *wsSubscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sub := c.subs[id]
+	delete(c.subs, id)
+	return sub
+}
+
+// finish ends a subscription the server has stopped, sending err to it first if there is one
+func (c *WebsocketConn) finish(id string, err error) {
+	sub := c.remove(id)
+	if sub == nil {
+		return
+	}
+	if err != nil {
+		sub.send(c.ctx, Message{Err: err})
+	}
+	sub.close()
+	sub.cancel()
+}
+
+// shutdown ends every subscription once the connection is gone for good
+func (c *WebsocketConn) shutdown(err error) {
+	c.mu.Lock()
+	c.closed = true
+	subs := c.subs
+	c.subs = map[string]*wsSubscription{}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		if err != nil {
+			sub.send(c.ctx, Message{Err: err})
+		}
+		sub.close()
+		sub.cancel()
+	}
+}
 
-			if respDataRaw["errors"] != nil {
-				var errs []*errors.QueryError
-				if err = unpack(respDataRaw["errors"], errs); err != nil {
-					return err
-				}
-				if len(errs) > 0 {
-					return fmt.Errorf("errors: %s", errs)
-				}
+// send blocks until the message is read, or either the subscription or connection is done
+func (s *wsSubscription) send(connCtx context.Context, msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.messages <- msg:
+	case <-s.ctx.Done():
+	case <-connCtx.Done():
+	}
+}
+
+func (s *wsSubscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.messages)
+	}
+}
+
+// Subscription is a simpler interface to a subscription on its own connection, used for testing.
+type Subscription struct {
+	Close func() error
+	Next  func(response interface{}) error
+}
+
+func errorSubscription(err error) *Subscription {
+	return &Subscription{
+		Close: func() error { return nil },
+		Next: func(response interface{}) error {
+			return err
+		},
+	}
+}
+
+// Websocket dials a new connection for a single subscription. Like Post its results are strictly decoded, any
+// graphql errors or fields missing from response are an error.
+func (p *Client) Websocket(query string, options ...Option) *Subscription {
+	conn, err := p.DialWebsocket(context.Background())
+	if err != nil {
+		return errorSubscription(err)
+	}
+	messages := conn.Subscribe(context.Background(), query, options...)
+
+	return &Subscription{
+		Close: conn.Close,
+		Next: func(response interface{}) error {
+			msg, ok := <-messages
+			if !ok {
+				return fmt.Errorf("subscription complete")
+			}
+			if msg.Err != nil {
+				return msg.Err
+			}
+			if len(msg.Errors) > 0 {
+				return msg.Errors
 			}
 
-			return unpack(respDataRaw["data"], response)
+			var data interface{}
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return fmt.Errorf("decode: %s", err.Error())
+			}
+			return unpack(data, response)
 		},
 	}
 }
@@ -0,0 +1,255 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/client"
+)
+
+type wsMessage struct {
+	Payload json.RawMessage `json:"payload,omitempty"`
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+}
+
+// wsServer acks every connection and hands the start messages it receives to the test, along with the connection
+// to reply on.
+type wsServer struct {
+	*httptest.Server
+	inits  chan json.RawMessage
+	starts chan wsStart
+	stops  chan string
+}
+
+type wsStart struct {
+	wsMessage
+	conn *websocket.Conn
+	mu   *sync.Mutex
+}
+
+func (s wsStart) reply(t *testing.T, msgType string, payload string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg := wsMessage{ID: s.ID, Type: msgType}
+	if payload != "" {
+		msg.Payload = json.RawMessage(payload)
+	}
+	require.NoError(t, s.conn.WriteJSON(msg))
+}
+
+func newWsServer() *wsServer {
+	s := &wsServer{
+		inits:  make(chan json.RawMessage, 10),
+		starts: make(chan wsStart, 10),
+		stops:  make(chan string, 10),
+	}
+	upgrader := websocket.Upgrader{Subprotocols: []string{"graphql-ws"}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer conn.Close()
+		mu := &sync.Mutex{}
+
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			switch msg.Type {
+			case "connection_init":
+				s.inits <- msg.Payload
+				mu.Lock()
+				_ = conn.WriteJSON(wsMessage{Type: "connection_ack"})
+				_ = conn.WriteJSON(wsMessage{Type: "ka"})
+				mu.Unlock()
+			case "start":
+				s.starts <- wsStart{wsMessage: msg, conn: conn, mu: mu}
+			case "stop":
+				s.stops <- msg.ID
+			case "connection_terminate":
+				return
+			}
+		}
+	}))
+	return s
+}
+
+func (s *wsServer) nextStart(t *testing.T) wsStart {
+	select {
+	case start := <-s.starts:
+		return start
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for start")
+		return wsStart{}
+	}
+}
+
+func nextMessage(t *testing.T, messages <-chan client.Message) (client.Message, bool) {
+	select {
+	case msg, ok := <-messages:
+		return msg, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return client.Message{}, false
+	}
+}
+
+func TestWebsocketConn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("multiplexes subscriptions", func(t *testing.T) {
+		srv := newWsServer()
+		defer srv.Close()
+
+		conn, err := client.New(srv.URL).DialWebsocket(ctx, client.WebsocketInitPayload(map[string]interface{}{"token": "abc"}))
+		require.NoError(t, err)
+		defer conn.Close()
+		require.JSONEq(t, `{"token":"abc"}`, string(<-srv.inits))
+
+		a := conn.Subscribe(ctx, "subscription { a }")
+		startA := srv.nextStart(t)
+		b := conn.Subscribe(ctx, "subscription { b }", client.Var("id", 1))
+		startB := srv.nextStart(t)
+		require.NotEqual(t, startA.ID, startB.ID)
+		require.JSONEq(t, `{"query":"subscription { b }","variables":{"id":1}}`, string(startB.Payload))
+
+		startB.reply(t, "data", `{"data":{"b":2}}`)
+		startA.reply(t, "data", `{"data":{"a":1},"errors":[{"message":"partial"}]}`)
+
+		msg, ok := nextMessage(t, b)
+		require.True(t, ok)
+		var respB struct{ B int }
+		require.NoError(t, msg.Decode(&respB))
+		require.Equal(t, 2, respB.B)
+
+		msg, ok = nextMessage(t, a)
+		require.True(t, ok)
+		require.EqualError(t, msg.Errors, "partial")
+		require.JSONEq(t, `{"a":1}`, string(msg.Data))
+
+		startA.reply(t, "complete", "")
+		_, ok = nextMessage(t, a)
+		require.False(t, ok)
+
+		startB.reply(t, "error", `[{"message":"bad query"}]`)
+		msg, ok = nextMessage(t, b)
+		require.True(t, ok)
+		require.EqualError(t, msg.Err, "bad query")
+		_, ok = nextMessage(t, b)
+		require.False(t, ok)
+	})
+
+	t.Run("cancelling the context stops the subscription", func(t *testing.T) {
+		srv := newWsServer()
+		defer srv.Close()
+
+		conn, err := client.New(srv.URL).DialWebsocket(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		subCtx, cancel := context.WithCancel(ctx)
+		messages := conn.Subscribe(subCtx, "subscription { a }")
+		start := srv.nextStart(t)
+
+		cancel()
+		_, ok := nextMessage(t, messages)
+		require.False(t, ok)
+		require.Equal(t, start.ID, <-srv.stops)
+	})
+
+	t.Run("close ends every subscription", func(t *testing.T) {
+		srv := newWsServer()
+		defer srv.Close()
+
+		conn, err := client.New(srv.URL).DialWebsocket(ctx)
+		require.NoError(t, err)
+
+		messages := conn.Subscribe(ctx, "subscription { a }")
+		srv.nextStart(t)
+
+		require.NoError(t, conn.Close())
+		_, ok := nextMessage(t, messages)
+		require.False(t, ok)
+
+		msg, ok := nextMessage(t, conn.Subscribe(ctx, "subscription { a }"))
+		require.True(t, ok)
+		require.EqualError(t, msg.Err, "websocket: connection closed")
+	})
+
+	t.Run("reconnects and resubscribes", func(t *testing.T) {
+		srv := newWsServer()
+		defer srv.Close()
+
+		conn, err := client.New(srv.URL).DialWebsocket(ctx, client.WebsocketReconnect(3, time.Millisecond))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		messages := conn.Subscribe(ctx, "subscription { a }")
+		start := srv.nextStart(t)
+		require.NoError(t, start.conn.Close())
+
+		restart := srv.nextStart(t)
+		require.Equal(t, start.ID, restart.ID)
+		require.Equal(t, start.Payload, restart.Payload)
+
+		restart.reply(t, "data", `{"data":{"a":1}}`)
+		msg, ok := nextMessage(t, messages)
+		require.True(t, ok)
+		require.JSONEq(t, `{"a":1}`, string(msg.Data))
+	})
+
+	t.Run("gives up when the connection is lost", func(t *testing.T) {
+		srv := newWsServer()
+
+		conn, err := client.New(srv.URL).DialWebsocket(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		messages := conn.Subscribe(ctx, "subscription { a }")
+		start := srv.nextStart(t)
+		srv.Close()
+		require.NoError(t, start.conn.Close())
+
+		msg, ok := nextMessage(t, messages)
+		require.True(t, ok)
+		require.Error(t, msg.Err)
+		_, ok = nextMessage(t, messages)
+		require.False(t, ok)
+	})
+
+	t.Run("keepalive timeout", func(t *testing.T) {
+		srv := newWsServer()
+		defer srv.Close()
+
+		conn, err := client.New(srv.URL).DialWebsocket(ctx, client.WebsocketKeepAliveTimeout(20*time.Millisecond))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		messages := conn.Subscribe(ctx, "subscription { a }")
+		srv.nextStart(t)
+
+		msg, ok := nextMessage(t, messages)
+		require.True(t, ok)
+		require.Contains(t, msg.Err.Error(), "timeout")
+	})
+
+	t.Run("dial respects the context", func(t *testing.T) {
+		srv := newWsServer()
+		defer srv.Close()
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := client.New(srv.URL).DialWebsocket(cancelled)
+		require.Equal(t, context.Canceled, err)
+	})
+}
@@ -20,6 +20,9 @@ type Build struct {
 	MutationRoot     *Object
 	SubscriptionRoot *Object
 	SchemaRaw        string
+	Federation       bool
+	ServiceSDL       string
+	Entities         []*Entity
 }
 
 type ModelBuild struct {
@@ -37,7 +40,7 @@ func (cfg *Config) models() (*ModelBuild, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "loading failed")
 	}
-	imports := buildImports(namedTypes, cfg.Model.Dir())
+	imports := buildImports(cfg.buildContext(), namedTypes, cfg.Model.Dir())
 
 	cfg.bindTypes(imports, namedTypes, cfg.Model.Dir(), prog)
 
@@ -55,6 +58,12 @@ func (cfg *Config) models() (*ModelBuild, error) {
 
 // bind a schema together with some code to generate a Build
 func (cfg *Config) bind() (*Build, error) {
+	return cfg.bindPackage(cfg.Exec)
+}
+
+// bindPackage binds the schema for code written into pkg, types from any other package, including the exec
+// package, are referred to through its imports.
+func (cfg *Config) bindPackage(pkg PackageConfig) (*Build, error) {
 	namedTypes := cfg.buildNamedTypes()
 
 	prog, err := cfg.loadProgram(namedTypes, true)
@@ -62,10 +71,16 @@ func (cfg *Config) bind() (*Build, error) {
 		return nil, errors.Wrap(err, "loading failed")
 	}
 
-	imports := buildImports(namedTypes, cfg.Exec.Dir())
-	cfg.bindTypes(imports, namedTypes, cfg.Exec.Dir(), prog)
+	imports := buildImports(cfg.buildContext(), namedTypes, pkg.Dir())
+	imports.add(cfg.Exec.ImportPath())
+	cfg.bindTypes(imports, namedTypes, pkg.Dir(), prog)
+
+	directives, err := cfg.buildDirectives(prog, imports)
+	if err != nil {
+		return nil, err
+	}
 
-	objects, err := cfg.buildObjects(namedTypes, prog, imports)
+	objects, err := cfg.buildObjects(namedTypes, prog, imports, directives)
 	if err != nil {
 		return nil, err
 	}
@@ -76,12 +91,12 @@ func (cfg *Config) bind() (*Build, error) {
 	}
 
 	b := &Build{
-		PackageName: cfg.Exec.Package,
+		PackageName: pkg.Package,
 		Objects:     objects,
 		Interfaces:  cfg.buildInterfaces(namedTypes, prog),
 		Inputs:      inputs,
 		Imports:     imports.finalize(),
-		SchemaRaw:   cfg.SchemaStr,
+		SchemaRaw:   cfg.schemaRaw,
 	}
 
 	if qr, ok := cfg.schema.EntryPoints["query"]; ok {
@@ -100,6 +115,12 @@ func (cfg *Config) bind() (*Build, error) {
 		return b, fmt.Errorf("query entry point missing")
 	}
 
+	if cfg.Federation {
+		if err := cfg.bindFederation(b, namedTypes); err != nil {
+			return nil, err
+		}
+	}
+
 	// Poke a few magic methods into query
 	q := b.Objects.ByName(b.QueryRoot.GQLType)
 	q.Fields = append(q.Fields, Field{
@@ -130,6 +151,14 @@ func (cfg *Config) validate() error {
 	return err
 }
 
+// buildContext sees the files generated so far as if they had been written
+func (cfg *Config) buildContext() *build.Context {
+	if cfg.overlay == nil {
+		return &build.Default
+	}
+	return cfg.overlay.context()
+}
+
 func (cfg *Config) loadProgram(namedTypes NamedTypes, allowErrors bool) (*loader.Program, error) {
 	conf := loader.Config{}
 	if allowErrors {
@@ -140,6 +169,7 @@ func (cfg *Config) loadProgram(namedTypes NamedTypes, allowErrors bool) (*loader
 			},
 		}
 	}
+	conf.Build = cfg.buildContext()
 	for _, imp := range ambientImports {
 		conf.Import(imp)
 	}
@@ -150,6 +180,12 @@ func (cfg *Config) loadProgram(namedTypes NamedTypes, allowErrors bool) (*loader
 		}
 	}
 
+	for _, directive := range cfg.Directives {
+		if pkg, _ := pkgAndType(directive.Implementation); pkg != "" {
+			conf.Import(pkg)
+		}
+	}
+
 	return conf.Load()
 }
 
@@ -0,0 +1,54 @@
+package codegen
+
+import (
+	"strconv"
+)
+
+type ClientBuild struct {
+	PackageName string
+	Imports     []*Import
+	Operations  []*ClientOperation
+	Structs     []*ClientStruct
+	Enums       []Enum
+}
+
+type ClientOperation struct {
+	Name     string // The name of the operation in graphql
+	GoName   string // The name of the method on the generated client
+	Type     string // query or mutation
+	Document string // The operation and the fragments it uses
+	Vars     []*ClientVar
+	Response *ClientStruct
+}
+
+type ClientVar struct {
+	*Type
+
+	GQLName   string // The name of the variable in graphql
+	GoVarName string // The name of the parameter in go
+	Optional  bool   // Nullable variables are only sent when they are set, so that defaults apply
+}
+
+// ClientStruct is either the result of a selection set or an input object.
+type ClientStruct struct {
+	GoType      string
+	Description string
+	Fields      []*ClientField
+}
+
+type ClientField struct {
+	*Type
+
+	GoName      string // The name of the struct field in go
+	JSONName    string // The name of the field in the response, its alias if it has one
+	OmitEmpty   bool   // Leave nullable input fields out when they are not set
+	Description string
+}
+
+func (f *ClientField) Tag() string {
+	tag := f.JSONName
+	if f.OmitEmpty {
+		tag += ",omitempty"
+	}
+	return "`json:" + strconv.Quote(tag) + "`"
+}
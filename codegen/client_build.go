@@ -0,0 +1,410 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlgen/codegen/templates"
+	"github.com/vektah/gqlgen/neelance/common"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
+	"github.com/vektah/gqlgen/neelance/validation"
+)
+
+// The go types used by the client for the scalars in the spec, they can be overridden in client.scalars. Any other
+// scalar is decoded into a json.RawMessage unless it is mapped there too.
+var clientScalars = map[string]string{
+	"Int":     "int",
+	"Float":   "float64",
+	"String":  "string",
+	"Boolean": "bool",
+	"ID":      "string",
+}
+
+type clientBuilder struct {
+	cfg     *Config
+	doc     *query.Document
+	imports *Imports
+	build   *ClientBuild
+
+	namedTypes NamedTypes      // The scalars, enums and inputs that have been used, by graphql name
+	goTypes    map[string]bool // Every type that has been declared, to catch collisions
+}
+
+func (cfg *Config) buildClient() (*ClientBuild, error) {
+	if len(cfg.Client.Operations) == 0 {
+		return nil, fmt.Errorf("client.operations is required")
+	}
+
+	sources, err := readFiles("operations", cfg.Client.Operations)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseOperations(cfg.schema, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &clientBuilder{
+		cfg:        cfg,
+		doc:        doc,
+		imports:    &Imports{destDir: cfg.Client.Dir()},
+		build:      &ClientBuild{PackageName: cfg.Client.Package},
+		namedTypes: NamedTypes{},
+		goTypes:    map[string]bool{},
+	}
+	b.imports.add("context")
+	b.imports.add("github.com/vektah/gqlgen/client")
+
+	for _, op := range doc.Operations {
+		if err := b.operation(op); err != nil {
+			return nil, err
+		}
+	}
+
+	b.build.Imports = b.imports.finalize()
+
+	// parameters must not shadow the imports or the names used in the generated method
+	reserved := map[string]bool{"ctx": true, "c": true, "resp": true, "err": true, "options": true}
+	for _, imp := range b.build.Imports {
+		reserved[imp.Alias()] = true
+	}
+	for _, op := range b.build.Operations {
+		for _, v := range op.Vars {
+			if token.Lookup(v.GoVarName).IsKeyword() || reserved[v.GoVarName] {
+				v.GoVarName += "Arg"
+			}
+		}
+	}
+
+	return b.build, nil
+}
+
+// parseOperations parses every operation file as a single document so that fragments can be shared between files,
+// and validates it against the schema. Errors are reported against the file they occurred in.
+func parseOperations(s *schema.Schema, sources []source) (*query.Document, error) {
+	var contents []string
+	for _, src := range sources {
+		if _, err := query.Parse(src.contents); err != nil {
+			return nil, errors.New(locateError(nil, src.filename, err))
+		}
+		contents = append(contents, src.contents)
+	}
+
+	doc, err := query.Parse(strings.Join(contents, "\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, err := range validation.Validate(s, doc) {
+		messages = append(messages, locateError(sources, "", err))
+	}
+	if len(messages) > 0 {
+		return nil, fmt.Errorf("invalid operations:\n%s", strings.Join(messages, "\n"))
+	}
+
+	return doc, nil
+}
+
+// locateError prefixes an error with the file and line it occurred on. If filename is empty the location is in
+// the joined sources and is mapped back to the file containing it.
+func locateError(sources []source, filename string, err *gqlerrors.QueryError) string {
+	if len(err.Locations) == 0 {
+		return filename + ": " + err.Message
+	}
+
+	line := err.Locations[0].Line
+	if filename == "" {
+		for _, src := range sources {
+			lines := strings.Count(src.contents, "\n") + 1
+			filename = src.filename
+			if line <= lines {
+				break
+			}
+			line -= lines
+		}
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", filename, line, err.Locations[0].Column, err.Message)
+}
+
+func (b *clientBuilder) operation(op *query.Operation) error {
+	if op.Name.Name == "" {
+		return fmt.Errorf("operations must be named to generate a client")
+	}
+	if op.Type == query.Subscription {
+		return fmt.Errorf("operation %s: subscriptions are not supported by the generated client", op.Name.Name)
+	}
+
+	opType := strings.ToLower(string(op.Type))
+	root := b.cfg.schema.EntryPoints[opType]
+	if root == nil {
+		return fmt.Errorf("operation %s: the schema does not have a %s type", op.Name.Name, opType)
+	}
+
+	operation := &ClientOperation{
+		Name:   op.Name.Name,
+		GoName: templates.ToCamel(op.Name.Name),
+		Type:   opType,
+		Document: query.Print(&query.Document{
+			Operations: query.OperationList{op},
+			Fragments:  b.doc.UsedFragments(op.Selections),
+		}),
+	}
+
+	for _, v := range op.Vars {
+		t, err := common.ResolveType(v.Type, b.cfg.schema.Resolve)
+		if err != nil {
+			return fmt.Errorf("operation %s: %s", op.Name.Name, err.Message)
+		}
+		typ, err2 := b.inputType(t)
+		if err2 != nil {
+			return errors.Wrapf(err2, "operation %s", op.Name.Name)
+		}
+		_, nonNull := t.(*common.NonNull)
+		operation.Vars = append(operation.Vars, &ClientVar{
+			Type:      typ,
+			GQLName:   v.Name.Name,
+			GoVarName: lcFirst(v.Name.Name),
+			Optional:  !nonNull,
+		})
+	}
+
+	response, err := b.selectionStruct(operation.GoName+"Response", operation.GoName, root, op.Selections)
+	if err != nil {
+		return errors.Wrapf(err, "operation %s", op.Name.Name)
+	}
+	operation.Response = response
+
+	b.build.Operations = append(b.build.Operations, operation)
+	return nil
+}
+
+type collectedField struct {
+	key        string
+	def        *schema.Field // nil for __typename
+	selections []query.Selection
+}
+
+// selectionStruct declares a struct for a selection set, fields of nested selections are named by prefixing their
+// response key with the name of the path leading to them.
+func (b *clientBuilder) selectionStruct(goType string, prefix string, parent schema.NamedType, sels []query.Selection) (*ClientStruct, error) {
+	if err := b.declare(goType); err != nil {
+		return nil, err
+	}
+	s := &ClientStruct{GoType: goType}
+	b.build.Structs = append(b.build.Structs, s)
+
+	fields, err := b.collectFields(parent, sels, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for _, f := range fields {
+		field := &ClientField{GoName: clientFieldName(f.key), JSONName: f.key}
+		if other, exists := names[field.GoName]; exists {
+			return nil, fmt.Errorf("%s.%s would be declared twice, for %s and %s, use an alias", goType, field.GoName, other, f.key)
+		}
+		names[field.GoName] = f.key
+
+		if f.def == nil {
+			field.Type = &Type{NamedType: &NamedType{Ref: Ref{GoType: "string"}}}
+			s.Fields = append(s.Fields, field)
+			continue
+		}
+		field.Description = f.def.Desc
+
+		var named *NamedType
+		switch t := unwrapType(f.def.Type).(type) {
+		case *schema.Object, *schema.Interface, *schema.Union:
+			child, err := b.selectionStruct(prefix+field.GoName, prefix+field.GoName, t, f.selections)
+			if err != nil {
+				return nil, err
+			}
+			named = &NamedType{Ref: Ref{GoType: child.GoType}, GQLType: t.TypeName()}
+		default:
+			if named, err = b.namedType(t); err != nil {
+				return nil, err
+			}
+		}
+
+		field.Type = NamedTypes{named.GQLType: named}.getType(f.def.Type)
+		s.Fields = append(s.Fields, field)
+	}
+
+	return s, nil
+}
+
+// collectFields flattens fragments into the fields of the selection set, merging fields with the same response key.
+// Fields selected on a more specific type are only set when the result is of that type.
+func (b *clientBuilder) collectFields(parent schema.NamedType, sels []query.Selection, fields []*collectedField) ([]*collectedField, error) {
+	var err error
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *query.Field:
+			var field *collectedField
+			for _, f := range fields {
+				if f.key == sel.Alias.Name {
+					field = f
+				}
+			}
+			if field == nil {
+				field = &collectedField{key: sel.Alias.Name}
+				if sel.Name.Name != "__typename" {
+					if field.def = b.fieldDef(parent, sel.Name.Name); field.def == nil {
+						return nil, fmt.Errorf("unknown field %s on %s", sel.Name.Name, parent.TypeName())
+					}
+				}
+				fields = append(fields, field)
+			}
+			field.selections = append(field.selections, sel.Selections...)
+
+		case *query.InlineFragment:
+			typ := parent
+			if sel.On.Name != "" {
+				typ = b.cfg.schema.Types[sel.On.Name]
+			}
+			if fields, err = b.collectFields(typ, sel.Selections, fields); err != nil {
+				return nil, err
+			}
+
+		case *query.FragmentSpread:
+			frag := b.doc.Fragments.Get(sel.Name.Name)
+			if fields, err = b.collectFields(b.cfg.schema.Types[frag.On.Name], frag.Selections, fields); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fields, nil
+}
+
+func (b *clientBuilder) fieldDef(parent schema.NamedType, name string) *schema.Field {
+	switch name {
+	case "__schema":
+		return &schema.Field{Name: name, Type: &common.NonNull{OfType: b.cfg.schema.Types["__Schema"]}}
+	case "__type":
+		return &schema.Field{Name: name, Type: b.cfg.schema.Types["__Type"]}
+	}
+
+	switch parent := parent.(type) {
+	case *schema.Object:
+		return parent.Fields.Get(name)
+	case *schema.Interface:
+		return parent.Fields.Get(name)
+	}
+	return nil
+}
+
+// inputType binds the type of a variable or an input field.
+func (b *clientBuilder) inputType(t common.Type) (*Type, error) {
+	named, err := b.namedType(unwrapType(t))
+	if err != nil {
+		return nil, err
+	}
+	return NamedTypes{named.GQLType: named}.getType(t), nil
+}
+
+// namedType binds a scalar, enum or input object, declaring enums and inputs the first time they are used.
+func (b *clientBuilder) namedType(t schema.NamedType) (*NamedType, error) {
+	if named, ok := b.namedTypes[t.TypeName()]; ok {
+		return named, nil
+	}
+
+	switch t := t.(type) {
+	case *schema.Scalar:
+		goType, ok := b.cfg.Client.Scalars[t.Name]
+		if !ok {
+			goType, ok = clientScalars[t.Name]
+		}
+		if !ok {
+			goType = "encoding/json.RawMessage"
+		}
+
+		named := &NamedType{GQLType: t.Name, IsScalar: true}
+		named.Package, named.GoType = pkgAndType(goType)
+		named.Import = b.imports.add(named.Package)
+		b.namedTypes[t.Name] = named
+		return named, nil
+
+	case *schema.Enum:
+		named := &NamedType{Ref: Ref{GoType: templates.ToCamel(t.Name)}, GQLType: t.Name, IsScalar: true}
+		if err := b.declare(named.GoType); err != nil {
+			return nil, err
+		}
+		b.namedTypes[t.Name] = named
+
+		enum := Enum{NamedType: named}
+		for _, v := range t.Values {
+			enum.Values = append(enum.Values, EnumValue{v.Name, v.Desc})
+		}
+		b.build.Enums = append(b.build.Enums, enum)
+		return named, nil
+
+	case *schema.InputObject:
+		named := &NamedType{Ref: Ref{GoType: ucFirst(t.Name)}, GQLType: t.Name, IsInput: true}
+		if err := b.declare(named.GoType); err != nil {
+			return nil, err
+		}
+		b.namedTypes[t.Name] = named
+
+		s := &ClientStruct{GoType: named.GoType, Description: t.Desc}
+		b.build.Structs = append(b.build.Structs, s)
+		names := map[string]string{}
+		for _, v := range t.Values {
+			goName := clientFieldName(v.Name.Name)
+			if other, exists := names[goName]; exists {
+				return nil, fmt.Errorf("%s.%s would be declared twice, for %s and %s", named.GoType, goName, other, v.Name.Name)
+			}
+			names[goName] = v.Name.Name
+
+			typ, err := b.inputType(v.Type)
+			if err != nil {
+				return nil, err
+			}
+			_, nonNull := v.Type.(*common.NonNull)
+			s.Fields = append(s.Fields, &ClientField{
+				Type:        typ,
+				GoName:      goName,
+				JSONName:    v.Name.Name,
+				OmitEmpty:   !nonNull,
+				Description: v.Desc,
+			})
+		}
+		return named, nil
+	}
+
+	return nil, fmt.Errorf("%s cannot be used here", t.TypeName())
+}
+
+func (b *clientBuilder) declare(goType string) error {
+	if b.goTypes[goType] {
+		return fmt.Errorf("%s would be declared twice, rename an operation or use an alias", goType)
+	}
+	b.goTypes[goType] = true
+	return nil
+}
+
+func clientFieldName(key string) string {
+	name := ucFirst(strings.TrimLeft(key, "_"))
+	if name == "Id" {
+		return "ID"
+	}
+	return name
+}
+
+func unwrapType(t common.Type) schema.NamedType {
+	for {
+		switch val := t.(type) {
+		case *common.NonNull:
+			t = val.OfType
+		case *common.List:
+			t = val.OfType
+		default:
+			return t.(schema.NamedType)
+		}
+	}
+}
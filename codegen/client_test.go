@@ -0,0 +1,131 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/loader"
+)
+
+const clientSchema = `
+	type Query {
+		user(id: ID!, type: Kind = ADMIN): User
+		search(filter: Filter): [Result!]!
+	}
+	type Mutation {
+		login(name: String!): User!
+	}
+	type Subscription {
+		joined: User!
+	}
+	enum Kind { ADMIN USER }
+	scalar Duration
+	input Filter {
+		name: String
+		kind: Kind!
+		tags: [String!]
+	}
+	type User {
+		id: ID!
+		name: String
+		session: Duration
+		friends(first: Int): [User]
+	}
+	type Group {
+		name: String!
+	}
+	union Result = User | Group
+`
+
+func TestGenerateClient(t *testing.T) {
+	err := generateClient("client", map[string]string{
+		"users.graphql": `
+			query User($id: ID!, $type: Kind) {
+				user(id: $id, type: $type) { ...UserFields friends(first: 2) { name } }
+			}
+			mutation Login($name: String!) { login(name: $name) { ...UserFields } }
+		`,
+		"search.graphql": `
+			query Search($filter: Filter) {
+				search(filter: $filter) { __typename ... on Group { name } ...UserFields }
+			}
+			fragment UserFields on User { id name session }
+		`,
+	}, map[string]string{"Duration": "time.Duration"})
+	require.NoError(t, err)
+
+	b, err := ioutil.ReadFile("testdata/gen/client/client.go")
+	require.NoError(t, err)
+	generated := string(b)
+
+	require.Contains(t, generated, "func (c *Client) User(ctx context.Context, id string, typeArg *Kind) (*UserResponse, error)")
+	require.Contains(t, generated, "func (c *Client) Search(ctx context.Context, filter *Filter) (*SearchResponse, error)")
+	require.Regexp(t, `Session\s+\*time.Duration\s+`+"`json:\"session\"`", generated)
+	require.Regexp(t, `Tags\s+\[\]string\s+`+"`json:\"tags,omitempty\"`", generated)
+	require.Regexp(t, `Friends\s+\[\]\*UserUserFriends\s+`, generated)
+
+	t.Run("validation errors point at the file", func(t *testing.T) {
+		err := generateClient("invalid", map[string]string{
+			"a.graphql": "query A { user(id: 1) { id } }\n",
+			"b.graphql": "query B {\n\tuser(id: 1) { missing }\n}\n",
+		}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), filepath.Join("testdata", "gen", "invalid", "ops", "b.graphql")+`:2:`)
+		require.Contains(t, err.Error(), `Cannot query field "missing" on type "User".`)
+	})
+
+	t.Run("operations must be named", func(t *testing.T) {
+		err := generateClient("anonymous", map[string]string{"a.graphql": "{ user(id: 1) { id } }"}, nil)
+		require.EqualError(t, err, "client plan failed: operations must be named to generate a client")
+	})
+
+	t.Run("subscriptions are not supported", func(t *testing.T) {
+		err := generateClient("subscription", map[string]string{"a.graphql": "subscription Joined { joined { id } }"}, nil)
+		require.EqualError(t, err, "client plan failed: operation Joined: subscriptions are not supported by the generated client")
+	})
+
+	t.Run("generated types must not collide", func(t *testing.T) {
+		err := generateClient("collision", map[string]string{"a.graphql": "query User { response: user(id: 1) { id } }"}, nil)
+		require.EqualError(t, err, "client plan failed: operation User: UserResponse would be declared twice, rename an operation or use an alias")
+	})
+
+	t.Run("field names must not collide", func(t *testing.T) {
+		err := generateClient("field_collision", map[string]string{"a.graphql": "query User { user(id: 1) { id _id: id } }"}, nil)
+		require.EqualError(t, err, "client plan failed: operation User: UserUser.ID would be declared twice, for id and _id, use an alias")
+
+		err = generateClient("field_collision", map[string]string{"a.graphql": "query User { user(id: 1) { name Name: name } }"}, nil)
+		require.EqualError(t, err, "client plan failed: operation User: UserUser.Name would be declared twice, for name and Name, use an alias")
+	})
+}
+
+func generateClient(name string, operations map[string]string, scalars map[string]string) error {
+	dir := "testdata/gen/" + name
+	if err := os.MkdirAll(dir+"/ops", 0755); err != nil {
+		return err
+	}
+	for filename, contents := range operations {
+		if err := ioutil.WriteFile(dir+"/ops/"+filename, []byte(contents), 0644); err != nil {
+			return err
+		}
+	}
+
+	cfg := Config{SchemaStr: clientSchema}
+	cfg.Client.Filename = dir + "/client.go"
+	cfg.Client.Operations = []string{filepath.Join(dir, "ops", "*.graphql")}
+	cfg.Client.Scalars = scalars
+
+	err := GenerateClient(cfg)
+	if err == nil {
+		conf := loader.Config{}
+		conf.Import("github.com/vektah/gqlgen/codegen/" + dir)
+
+		_, err = conf.Load()
+		if err != nil {
+			panic(err)
+		}
+	}
+	return err
+}
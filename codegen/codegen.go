@@ -2,26 +2,45 @@ package codegen
 
 import (
 	"bytes"
-	"fmt"
-	"io/ioutil"
-	"os"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"path/filepath"
 	"regexp"
-	"syscall"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/vektah/gqlgen/codegen/templates"
-	"github.com/vektah/gqlgen/neelance/schema"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/imports"
 )
 
+// Generate writes the server, models and resolver stubs. They are generated and type checked in memory first, so
+// nothing is written unless generation succeeds, and files that have not changed are left untouched.
 func Generate(cfg Config) error {
+	if err := cfg.generate(); err != nil {
+		return err
+	}
+	return cfg.overlay.commit()
+}
+
+// Stale generates the server in memory and lists the files that Generate would change, without writing anything.
+func Stale(cfg Config) ([]string, error) {
+	if err := cfg.generate(); err != nil {
+		return nil, err
+	}
+	return cfg.overlay.stale()
+}
+
+func (cfg *Config) generate() error {
 	if err := cfg.normalize(); err != nil {
 		return err
 	}
 
-	_ = syscall.Unlink(cfg.Exec.Filename)
-	_ = syscall.Unlink(cfg.Model.Filename)
+	// the previous output is hidden, it may not even compile against the current schema
+	cfg.overlay = newOverlay()
+	cfg.overlay.remove(cfg.Exec.Filename)
+	cfg.overlay.remove(cfg.Model.Filename)
 
 	modelsBuild, err := cfg.models()
 	if err != nil {
@@ -34,9 +53,7 @@ func Generate(cfg Config) error {
 			return errors.Wrap(err, "model generation failed")
 		}
 
-		if err = write(cfg.Model.Filename, buf.Bytes()); err != nil {
-			return err
-		}
+		cfg.overlay.write(cfg.Model.Filename, buf.Bytes())
 		for _, model := range modelsBuild.Models {
 			modelCfg := cfg.Models[model.GQLType]
 			modelCfg.Model = cfg.Model.ImportPath() + "." + model.GoType
@@ -61,8 +78,12 @@ func Generate(cfg Config) error {
 		return errors.Wrap(err, "exec codegen failed")
 	}
 
-	if err = write(cfg.Exec.Filename, buf.Bytes()); err != nil {
-		return err
+	cfg.overlay.write(cfg.Exec.Filename, buf.Bytes())
+
+	if cfg.Resolver.Filename != "" {
+		if err = cfg.generateResolver(); err != nil {
+			return err
+		}
 	}
 
 	if err = cfg.validate(); err != nil {
@@ -72,6 +93,96 @@ func Generate(cfg Config) error {
 	return nil
 }
 
+// GenerateClient writes a typed client for the operations listed in the client config, if it has changed.
+func GenerateClient(cfg Config) error {
+	if err := cfg.generateClient(); err != nil {
+		return err
+	}
+	return cfg.overlay.commit()
+}
+
+// StaleClient lists the files that GenerateClient would change, without writing anything.
+func StaleClient(cfg Config) ([]string, error) {
+	if err := cfg.generateClient(); err != nil {
+		return nil, err
+	}
+	return cfg.overlay.stale()
+}
+
+func (cfg *Config) generateClient() error {
+	if err := cfg.Client.normalize(); err != nil {
+		return errors.Wrap(err, "client")
+	}
+
+	if err := cfg.parseSchema(); err != nil {
+		return err
+	}
+
+	cfg.overlay = newOverlay()
+
+	build, err := cfg.buildClient()
+	if err != nil {
+		return errors.Wrap(err, "client plan failed")
+	}
+
+	buf, err := templates.Run("client.gotpl", build)
+	if err != nil {
+		return errors.Wrap(err, "client codegen failed")
+	}
+
+	cfg.overlay.write(cfg.Client.Filename, buf.Bytes())
+	return nil
+}
+
+// generateResolver writes the resolver stubs, or adds the declarations an existing file is missing to the end of it.
+func (cfg *Config) generateResolver() error {
+	build, err := cfg.resolver()
+	if err != nil {
+		return errors.Wrap(err, "resolver plan failed")
+	}
+	if !build.Missing() {
+		return nil
+	}
+
+	buf, err := templates.Run("resolver.gotpl", build)
+	if err != nil {
+		return errors.Wrap(err, "resolver codegen failed")
+	}
+	if !build.Append {
+		cfg.overlay.write(cfg.Resolver.Filename, buf.Bytes())
+		return nil
+	}
+
+	existing, err := readFile(cfg.buildContext(), cfg.Resolver.Filename)
+	if err != nil {
+		return errors.Wrap(err, "unable to read existing resolver")
+	}
+
+	// the new declarations may refer to packages the file does not import yet, any that end up unused are removed by
+	// goimports when it is written
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, cfg.Resolver.Filename, append(existing, buf.Bytes()...), parser.ParseComments)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse resolver")
+	}
+	imported := map[string]bool{}
+	for _, spec := range file.Imports {
+		imported[spec.Path.Value] = true
+	}
+	for _, imp := range build.Imports {
+		if !imported[strconv.Quote(imp.Path)] {
+			astutil.AddNamedImport(fset, file, imp.Alias(), imp.Path)
+		}
+	}
+
+	var merged bytes.Buffer
+	if err = format.Node(&merged, fset, file); err != nil {
+		return errors.Wrap(err, "unable to format resolver")
+	}
+	cfg.overlay.write(cfg.Resolver.Filename, merged.Bytes())
+	return nil
+}
+
 func (cfg *Config) normalize() error {
 	if err := cfg.Model.normalize(); err != nil {
 		return errors.Wrap(err, "model")
@@ -81,6 +192,12 @@ func (cfg *Config) normalize() error {
 		return errors.Wrap(err, "exec")
 	}
 
+	if cfg.Resolver.Filename != "" {
+		if err := cfg.Resolver.normalize(); err != nil {
+			return errors.Wrap(err, "resolver")
+		}
+	}
+
 	builtins := TypeMap{
 		"__Directive":  {Model: "github.com/vektah/gqlgen/neelance/introspection.Directive"},
 		"__Type":       {Model: "github.com/vektah/gqlgen/neelance/introspection.Type"},
@@ -95,6 +212,7 @@ func (cfg *Config) normalize() error {
 		"ID":           {Model: "github.com/vektah/gqlgen/graphql.ID"},
 		"Time":         {Model: "github.com/vektah/gqlgen/graphql.Time"},
 		"Map":          {Model: "github.com/vektah/gqlgen/graphql.Map"},
+		"Upload":       {Model: "github.com/vektah/gqlgen/graphql.Upload"},
 	}
 
 	if cfg.Models == nil {
@@ -106,8 +224,15 @@ func (cfg *Config) normalize() error {
 		}
 	}
 
-	cfg.schema = schema.New()
-	return cfg.schema.Parse(cfg.SchemaStr)
+	if cfg.Federation {
+		for typeName, entry := range federationModels {
+			if !cfg.Models.Exists(typeName) {
+				cfg.Models[typeName] = entry
+			}
+		}
+	}
+
+	return cfg.parseSchema()
 }
 
 var invalidPackageNameChar = regexp.MustCompile(`[^\w]`)
@@ -131,23 +256,3 @@ func gofmt(filename string, b []byte) ([]byte, error) {
 	}
 	return out, nil
 }
-
-func write(filename string, b []byte) error {
-	err := os.MkdirAll(filepath.Dir(filename), 0755)
-	if err != nil {
-		return errors.Wrap(err, "failed to create directory")
-	}
-
-	formatted, err := gofmt(filename, b)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "gofmt failed: %s\n", err.Error())
-		formatted = b
-	}
-
-	err = ioutil.WriteFile(filename, formatted, 0644)
-	if err != nil {
-		return errors.Wrapf(err, "failed to write %s", filename)
-	}
-
-	return nil
-}
@@ -6,15 +6,17 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
 	"github.com/vektah/gqlgen/neelance/schema"
 	"gopkg.in/yaml.v2"
 )
 
 var defaults = Config{
-	SchemaFilename: "schema.graphql",
+	SchemaFilename: SchemaFilenames{"schema.graphql"},
 	Model:          PackageConfig{Filename: "models_gen.go"},
 	Exec:           PackageConfig{Filename: "generated.go"},
 }
@@ -54,13 +56,134 @@ func LoadConfig(filename string) (*Config, error) {
 }
 
 type Config struct {
-	SchemaFilename string        `yaml:"schema,omitempty"`
-	SchemaStr      string        `yaml:"-"`
-	Exec           PackageConfig `yaml:"exec"`
-	Model          PackageConfig `yaml:"model"`
-	Models         TypeMap       `yaml:"models,omitempty"`
+	SchemaFilename SchemaFilenames `yaml:"schema,omitempty"`
+	SchemaStr      string          `yaml:"-"`
+	Exec           PackageConfig   `yaml:"exec"`
+	Model          PackageConfig   `yaml:"model"`
+	Models         TypeMap         `yaml:"models,omitempty"`
+	Directives     DirectiveMap    `yaml:"directives,omitempty"`
+	Federation     bool            `yaml:"federation,omitempty"`
+	Client         ClientConfig    `yaml:"client,omitempty"`
+	Resolver       ResolverConfig  `yaml:"resolver,omitempty"`
 
-	schema *schema.Schema `yaml:"-"`
+	schema        *schema.Schema `yaml:"-"`
+	schemaRaw     string         `yaml:"-"` // The schema that was parsed, including any declarations added by codegen
+	schemaSources []source       `yaml:"-"` // The files SchemaStr was loaded from, to report where errors in it are
+	overlay       *overlay       `yaml:"-"` // The files generated so far, they are only written once generation succeeds
+}
+
+// SchemaFilenames lists the schema files that are merged together, each may be a glob. In gqlgen.yml it can be
+// given as a single filename or a list.
+type SchemaFilenames []string
+
+func (a *SchemaFilenames) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*a = SchemaFilenames{single}
+		return nil
+	}
+
+	var multi []string
+	if err := unmarshal(&multi); err != nil {
+		return err
+	}
+	*a = multi
+	return nil
+}
+
+func (a SchemaFilenames) MarshalYAML() (interface{}, error) {
+	if len(a) == 1 {
+		return a[0], nil
+	}
+	return []string(a), nil
+}
+
+// LoadSchema reads every schema file into SchemaStr, in the order they are listed. Files matched by a glob are
+// read in lexical order.
+func (cfg *Config) LoadSchema() error {
+	sources, err := readFiles("schema", cfg.SchemaFilename)
+	if err != nil {
+		return err
+	}
+
+	var schemas []string
+	for _, source := range sources {
+		if err := schema.ParseSyntax(source.contents); err != nil {
+			return errors.New(locateError(nil, source.filename, err))
+		}
+		schemas = append(schemas, source.contents)
+	}
+	cfg.SchemaStr = strings.Join(schemas, "\n")
+	cfg.schemaSources = sources
+	return nil
+}
+
+// parseSchema parses SchemaStr, adding the federation types when they are enabled
+func (cfg *Config) parseSchema() error {
+	cfg.schemaRaw = cfg.SchemaStr
+	if cfg.Federation {
+		var err error
+		if cfg.schemaRaw, err = federationSchema(cfg.SchemaStr); err != nil {
+			return cfg.schemaError(err)
+		}
+	}
+
+	cfg.schema = schema.New()
+	if err := cfg.schema.Parse(cfg.schemaRaw); err != nil {
+		return cfg.schemaError(err)
+	}
+	return nil
+}
+
+// schemaError maps the location of an error in the merged schema back to the file it came from, when the schema was
+// loaded from files.
+func (cfg *Config) schemaError(err error) error {
+	queryErr, ok := err.(*gqlerrors.QueryError)
+	if !ok || len(queryErr.Locations) == 0 || len(cfg.schemaSources) == 0 {
+		return err
+	}
+
+	sources := cfg.schemaSources
+	if cfg.Federation {
+		// the prelude is added in front of the schema without a newline in between
+		prelude := source{filename: "federation prelude", contents: strings.TrimSuffix(federationPrelude, "\n")}
+		sources = append([]source{prelude}, sources...)
+	}
+	return errors.New(locateError(sources, "", queryErr))
+}
+
+type source struct {
+	filename string
+	contents string
+}
+
+// readFiles reads every file matching the patterns, each file is only read once.
+func readFiles(kind string, patterns []string) ([]source, error) {
+	var sources []source
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s filename %s", kind, pattern)
+		}
+		if len(matches) == 0 {
+			return nil, errors.Errorf("unable to open %s: no files match %s", kind, pattern)
+		}
+
+		for _, filename := range matches {
+			if seen[filename] {
+				continue
+			}
+			seen[filename] = true
+
+			b, err := ioutil.ReadFile(filename)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to open %s", kind)
+			}
+			sources = append(sources, source{filename, string(b)})
+		}
+	}
+	return sources, nil
 }
 
 type PackageConfig struct {
@@ -68,13 +191,56 @@ type PackageConfig struct {
 	Package  string `yaml:"package,omitempty"`
 }
 
+// ClientConfig configures the typed client that is generated from operation documents.
+type ClientConfig struct {
+	PackageConfig `yaml:",inline"`
+	Operations    []string          `yaml:"operations,omitempty"` // Files containing the operations, each may be a glob
+	Scalars       map[string]string `yaml:"scalars,omitempty"`    // Go types for custom scalars, these must support encoding/json
+}
+
+func (c *ClientConfig) Check() error {
+	if err := c.PackageConfig.Check(); err != nil {
+		return err
+	}
+	for scalar, goType := range c.Scalars {
+		if strings.LastIndex(goType, ".") < strings.LastIndex(goType, "/") {
+			return fmt.Errorf("scalar %s: invalid type specifier \"%s\" - you need to specify a type to map to", scalar, goType)
+		}
+	}
+	return nil
+}
+
+var goIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ResolverConfig configures the resolver stubs, they are only generated when a filename is set.
+type ResolverConfig struct {
+	PackageConfig `yaml:",inline"`
+	Type          string `yaml:"type,omitempty"` // The name of the root resolver struct, defaults to Resolver
+}
+
+func (c *ResolverConfig) Check() error {
+	if err := c.PackageConfig.Check(); err != nil {
+		return err
+	}
+	if c.Type != "" && !goIdentifier.MatchString(c.Type) {
+		return fmt.Errorf("type should be the name of a struct, got \"%s\"", c.Type)
+	}
+	return nil
+}
+
 type TypeMapEntry struct {
 	Model  string                  `yaml:"model"`
 	Fields map[string]TypeMapField `yaml:"fields,omitempty"`
 }
 
 type TypeMapField struct {
-	Resolver bool `yaml:"resolver"`
+	Resolver    bool     `yaml:"resolver"`
+	Complexity  int      `yaml:"complexity,omitempty"`
+	Multipliers []string `yaml:"multipliers,omitempty"`
+}
+
+type DirectiveMapEntry struct {
+	Implementation string `yaml:"implementation"`
 }
 
 func (c *PackageConfig) normalize() error {
@@ -130,12 +296,21 @@ func (cfg *Config) Check() error {
 	if err := cfg.Models.Check(); err != nil {
 		return errors.Wrap(err, "config.models")
 	}
+	if err := cfg.Directives.Check(); err != nil {
+		return errors.Wrap(err, "config.directives")
+	}
 	if err := cfg.Exec.Check(); err != nil {
 		return errors.Wrap(err, "config.exec")
 	}
 	if err := cfg.Model.Check(); err != nil {
 		return errors.Wrap(err, "config.model")
 	}
+	if err := cfg.Client.Check(); err != nil {
+		return errors.Wrap(err, "config.client")
+	}
+	if err := cfg.Resolver.Check(); err != nil {
+		return errors.Wrap(err, "config.resolver")
+	}
 	return nil
 }
 
@@ -155,6 +330,17 @@ func (tm TypeMap) Check() error {
 	return nil
 }
 
+type DirectiveMap map[string]DirectiveMapEntry
+
+func (dm DirectiveMap) Check() error {
+	for name, entry := range dm {
+		if strings.LastIndex(entry.Implementation, ".") < strings.LastIndex(entry.Implementation, "/") {
+			return fmt.Errorf("directive %s: invalid implementation \"%s\" - you need to specify a func to call", name, entry.Implementation)
+		}
+	}
+	return nil
+}
+
 // findCfg searches for the config file in this directory and all parents up the tree
 // looking for the closest match
 func findCfg() (string, error) {
@@ -2,12 +2,14 @@ package codegen
 
 import (
 	"go/build"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -38,7 +40,7 @@ func TestLoadDefaultConfig(t *testing.T) {
 
 		cfg, err = LoadDefaultConfig()
 		require.NoError(t, err)
-		require.Equal(t, cfg.SchemaFilename, "inner")
+		require.Equal(t, cfg.SchemaFilename, SchemaFilenames{"inner"})
 	})
 
 	t.Run("will find config in parent dirs", func(t *testing.T) {
@@ -47,7 +49,7 @@ func TestLoadDefaultConfig(t *testing.T) {
 
 		cfg, err = LoadDefaultConfig()
 		require.NoError(t, err)
-		require.Equal(t, cfg.SchemaFilename, "outer")
+		require.Equal(t, cfg.SchemaFilename, SchemaFilenames{"outer"})
 	})
 
 	t.Run("will fallback to defaults", func(t *testing.T) {
@@ -56,7 +58,74 @@ func TestLoadDefaultConfig(t *testing.T) {
 
 		cfg, err = LoadDefaultConfig()
 		require.NoError(t, err)
-		require.Equal(t, cfg.SchemaFilename, "schema.graphql")
+		require.Equal(t, cfg.SchemaFilename, SchemaFilenames{"schema.graphql"})
+	})
+}
+
+func TestSchemaFilenames(t *testing.T) {
+	t.Run("can be a single filename", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, yaml.UnmarshalStrict([]byte("schema: schema.graphql"), &cfg))
+		require.Equal(t, SchemaFilenames{"schema.graphql"}, cfg.SchemaFilename)
+	})
+
+	t.Run("can be a list", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, yaml.UnmarshalStrict([]byte("schema: [base.graphql, schema/*.graphql]"), &cfg))
+		require.Equal(t, SchemaFilenames{"base.graphql", "schema/*.graphql"}, cfg.SchemaFilename)
+	})
+
+	t.Run("globs are merged in order", func(t *testing.T) {
+		cfg := Config{SchemaFilename: SchemaFilenames{"testdata/schema/base.graphql", "testdata/schema/*.graphql"}}
+		require.NoError(t, cfg.LoadSchema())
+
+		base, err := ioutil.ReadFile("testdata/schema/base.graphql")
+		require.NoError(t, err)
+		users, err := ioutil.ReadFile("testdata/schema/users.graphql")
+		require.NoError(t, err)
+		require.Equal(t, string(base)+"\n"+string(users), cfg.SchemaStr)
+	})
+
+	t.Run("globs must match a file", func(t *testing.T) {
+		cfg := Config{SchemaFilename: SchemaFilenames{"testdata/schema/*.gql"}}
+		require.EqualError(t, cfg.LoadSchema(), "unable to open schema: no files match testdata/schema/*.gql")
+	})
+
+	t.Run("errors are reported in the file they occur in", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "schema")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		base := filepath.Join(dir, "base.graphql")
+		users := filepath.Join(dir, "users.graphql")
+		require.NoError(t, ioutil.WriteFile(base, []byte("type Query {\n\tuser: User\n}\n"), 0644))
+
+		require.NoError(t, ioutil.WriteFile(users, []byte("type User {\n\tid: ID!\n\tname String\n}\n"), 0644))
+		cfg := Config{SchemaFilename: SchemaFilenames{base, users}}
+		require.EqualError(t, cfg.LoadSchema(), users+`:3:7: syntax error: unexpected "String", expecting ":"`)
+
+		require.NoError(t, ioutil.WriteFile(users, []byte("type User {\n\tid: ID!\n\trole: Role\n}\n"), 0644))
+		cfg = Config{SchemaFilename: SchemaFilenames{base, users}}
+		require.NoError(t, cfg.LoadSchema())
+		require.EqualError(t, cfg.parseSchema(), users+`:3:8: Unknown type "Role".`)
+
+		cfg.Federation = true
+		require.EqualError(t, cfg.parseSchema(), users+`:3:8: Unknown type "Role".`)
+	})
+
+	t.Run("extensions in other files are generated", func(t *testing.T) {
+		cfg := Config{
+			SchemaFilename: SchemaFilenames{"testdata/schema/*.graphql"},
+			Exec:           PackageConfig{Filename: "testdata/gen/multischema/exec.go"},
+			Model:          PackageConfig{Filename: "testdata/gen/multischema/model.go"},
+		}
+		require.NoError(t, cfg.LoadSchema())
+		require.NoError(t, Generate(cfg))
+
+		models, err := ioutil.ReadFile("testdata/gen/multischema/model.go")
+		require.NoError(t, err)
+		require.Regexp(t, `RoleUser\s+Role = "USER"`, string(models))
+		require.Regexp(t, `Role\s+\*Role\s+`+"`json:\"role\"`", string(models))
 	})
 }
 
@@ -0,0 +1,8 @@
+package codegen
+
+type Directive struct {
+	*Ref
+
+	GQLName string                 // The name of the directive in graphql
+	Args    map[string]interface{} // The arguments given to the directive in the schema
+}
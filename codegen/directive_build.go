@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"go/types"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlgen/neelance/common"
+	"golang.org/x/tools/go/loader"
+)
+
+// buildDirectives resolves the go implementation for every directive in the directive map
+func (cfg *Config) buildDirectives(prog *loader.Program, imports *Imports) (map[string]*Ref, error) {
+	directives := map[string]*Ref{}
+
+	for name, entry := range cfg.Directives {
+		if _, ok := cfg.schema.Directives[name]; !ok {
+			return nil, errors.Errorf("directive %s is not declared in the schema", name)
+		}
+
+		ref := &Ref{IsUserDefined: true}
+		ref.Package, ref.GoType = pkgAndType(entry.Implementation)
+
+		def, err := findGoType(prog, ref.Package, ref.GoType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "directive %s", name)
+		}
+		if _, isFunc := def.(*types.Func); !isFunc {
+			return nil, errors.Errorf("directive %s: expected %s to be a func, instead found %T", name, entry.Implementation, def)
+		}
+
+		ref.Import = imports.add(ref.Package)
+		directives[name] = ref
+	}
+
+	return directives, nil
+}
+
+// fieldDirectives returns the directives applied to a field that have an implementation
+func fieldDirectives(directives map[string]*Ref, list common.DirectiveList) []Directive {
+	var fieldDirectives []Directive
+	for _, d := range list {
+		ref, ok := directives[d.Name.Name]
+		if !ok {
+			continue
+		}
+
+		args := map[string]interface{}{}
+		for _, arg := range d.Args {
+			if arg.Value == nil {
+				args[arg.Name.Name] = nil
+				continue
+			}
+			args[arg.Name.Name] = arg.Value.Value(nil)
+		}
+
+		fieldDirectives = append(fieldDirectives, Directive{
+			Ref:     ref,
+			GQLName: d.Name.Name,
+			Args:    args,
+		})
+	}
+	return fieldDirectives
+}
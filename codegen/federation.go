@@ -0,0 +1,62 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Entity struct {
+	*Object
+
+	Keys     []*EntityKey
+	Requires []*Field // External fields that other fields @require, which are copied from the representation
+}
+
+type EntityKey struct {
+	Entity *Entity
+	Fields []FieldArgument // The fields named by the @key, passed to the resolver that finds the entity
+}
+
+// ResolverName is the name of the resolver that finds an entity by this key, eg findUserById
+func (k *EntityKey) ResolverName() string {
+	name := "find" + k.Entity.GQLType + "By"
+	for i, f := range k.Fields {
+		if i > 0 {
+			name += "And"
+		}
+		name += ucFirst(f.GQLName)
+	}
+	return name
+}
+
+func (k *EntityKey) ResolverDeclaration() string {
+	res := fmt.Sprintf("Entity_%s(ctx context.Context", k.ResolverName())
+	for _, arg := range k.Fields {
+		res += fmt.Sprintf(", %s %s", arg.GoVarName, arg.Signature())
+	}
+	res += fmt.Sprintf(") (*%s, error)", k.Entity.FullName())
+	return res
+}
+
+func (k *EntityKey) ShortResolverDeclaration() string {
+	return ucFirst(strings.TrimPrefix(k.ResolverDeclaration(), "Entity_"))
+}
+
+func (k *EntityKey) ShortInvocation() string {
+	res := fmt.Sprintf("Entity().%s(ctx", ucFirst(k.ResolverName()))
+	for _, arg := range k.Fields {
+		res += fmt.Sprintf(", %s", arg.GoVarName)
+	}
+	res += ")"
+	return res
+}
+
+// Condition checks that a representation holds every field of this key
+func (k *EntityKey) Condition(rep string) string {
+	var conditions []string
+	for _, f := range k.Fields {
+		conditions = append(conditions, fmt.Sprintf("%s[%s] != nil", rep, strconv.Quote(f.GQLName)))
+	}
+	return strings.Join(conditions, " && ")
+}
@@ -0,0 +1,243 @@
+package codegen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// federationPrelude declares the scalars and directives that a federated schema may use
+const federationPrelude = `
+scalar _Any
+scalar _FieldSet
+
+directive @external on FIELD_DEFINITION
+directive @requires(fields: _FieldSet!) on FIELD_DEFINITION
+directive @provides(fields: _FieldSet!) on FIELD_DEFINITION
+directive @key(fields: _FieldSet!) on OBJECT | INTERFACE
+directive @extends on OBJECT | INTERFACE
+`
+
+var federationModels = TypeMap{
+	"_Any":      {Model: "github.com/vektah/gqlgen/graphql.Map"},
+	"_FieldSet": {Model: "github.com/vektah/gqlgen/graphql.String"},
+	"_Service":  {Model: "github.com/vektah/gqlgen/federation.Service"},
+	"_Entity":   {Model: "github.com/vektah/gqlgen/federation.Entity"},
+}
+
+// federationSchema adds the federation prelude to the schema, along with the _service and _entities fields the
+// gateway uses to query this service. _entities is only added when there are types with a @key.
+func federationSchema(sdl string) (string, error) {
+	s := schema.New()
+	if err := s.Parse(federationPrelude + sdl); err != nil {
+		return "", err
+	}
+
+	var entities []string
+	for name, typ := range s.Types {
+		if obj, ok := typ.(*schema.Object); ok && obj.Directives.Get("key") != nil {
+			entities = append(entities, name)
+		}
+	}
+	sort.Strings(entities)
+
+	full := federationPrelude + sdl + `
+type _Service {
+	sdl: String
+}
+`
+	if len(entities) == 0 {
+		return full + `
+extend type Query {
+	_service: _Service!
+}
+`, nil
+	}
+
+	return full + `
+union _Entity = ` + strings.Join(entities, " | ") + `
+
+extend type Query {
+	_entities(representations: [_Any!]!): [_Entity]!
+	_service: _Service!
+}
+`, nil
+}
+
+// bindFederation points the _service and _entities fields at the generated code that resolves them, and finds the
+// entities that need resolvers.
+func (cfg *Config) bindFederation(b *Build, types NamedTypes) error {
+	if _, ok := cfg.schema.Types["Entity"]; ok {
+		return errors.New("federation: the Entity type conflicts with the generated entity resolvers")
+	}
+
+	b.Federation = true
+	b.ServiceSDL = cfg.SchemaStr
+
+	for i := range b.QueryRoot.Fields {
+		field := &b.QueryRoot.Fields[i]
+		switch field.GQLName {
+		case "_service":
+			field.GoMethodName = "ec.federationService"
+			field.NoErr = true
+		case "_entities":
+			field.GoMethodName = "ec.federationEntities"
+			field.HasContext = true
+			// entities that could not be resolved are left nil in the slice, rather than using pointers
+			field.Type.Modifiers = []string{modList}
+		}
+	}
+
+	for _, obj := range b.Objects {
+		entity, err := cfg.buildEntity(types, obj)
+		if err != nil {
+			return err
+		}
+		if entity != nil {
+			b.Entities = append(b.Entities, entity)
+		}
+	}
+	return nil
+}
+
+// buildEntity checks the federation directives on an object, returning the entity it defines if it has a @key
+func (cfg *Config) buildEntity(types NamedTypes, obj *Object) (*Entity, error) {
+	schemaObj := cfg.schema.Types[obj.GQLType].(*schema.Object)
+	entity := &Entity{Object: obj}
+
+	for _, d := range schemaObj.Directives {
+		if d.Name.Name != "key" {
+			continue
+		}
+		names, err := fieldSet(d)
+		if err != nil {
+			return nil, errors.Wrapf(err, "@key on %s", obj.GQLType)
+		}
+
+		key := &EntityKey{Entity: entity}
+		for _, name := range names {
+			field := schemaObj.Fields.Get(name)
+			if field == nil {
+				return nil, errors.Errorf("@key on %s: %s is not a field of %s", obj.GQLType, name, obj.GQLType)
+			}
+			key.Fields = append(key.Fields, FieldArgument{
+				GQLName:   name,
+				GoVarName: sanitizeGoName(name),
+				Type:      types.getType(field.Type),
+				Object:    obj,
+			})
+		}
+		entity.Keys = append(entity.Keys, key)
+	}
+
+	for _, field := range schemaObj.Fields {
+		if d := field.Directives.Get("requires"); d != nil {
+			names, err := fieldSet(d)
+			if err != nil {
+				return nil, errors.Wrapf(err, "@requires on %s.%s", obj.GQLType, field.Name)
+			}
+
+			for _, name := range names {
+				required, err := externalField(schemaObj, name)
+				if err != nil {
+					return nil, errors.Wrapf(err, "@requires on %s.%s", obj.GQLType, field.Name)
+				}
+				if err := entity.addRequired(required.Name); err != nil {
+					return nil, errors.Wrapf(err, "@requires on %s.%s", obj.GQLType, field.Name)
+				}
+			}
+		}
+
+		if d := field.Directives.Get("provides"); d != nil {
+			names, err := fieldSet(d)
+			if err != nil {
+				return nil, errors.Wrapf(err, "@provides on %s.%s", obj.GQLType, field.Name)
+			}
+
+			provided, ok := namedType(field.Type).(*schema.Object)
+			if !ok {
+				return nil, errors.Errorf("@provides on %s.%s: %s is not an object type", obj.GQLType, field.Name, field.Type)
+			}
+			for _, name := range names {
+				if _, err := externalField(provided, name); err != nil {
+					return nil, errors.Wrapf(err, "@provides on %s.%s", obj.GQLType, field.Name)
+				}
+			}
+		}
+	}
+
+	if len(entity.Keys) == 0 {
+		if len(entity.Requires) > 0 {
+			return nil, errors.Errorf("%s uses @requires but has no @key", obj.GQLType)
+		}
+		return nil, nil
+	}
+	return entity, nil
+}
+
+// addRequired copies a required field from the representation onto the entity, so it needs to be a field of the
+// go model rather than a resolver
+func (e *Entity) addRequired(name string) error {
+	for _, f := range e.Requires {
+		if f.GQLName == name {
+			return nil
+		}
+	}
+
+	for i := range e.Fields {
+		f := &e.Fields[i]
+		if f.GQLName != name {
+			continue
+		}
+		if f.GoVarName == "" {
+			return errors.Errorf("%s.%s must be bound to a field of %s", e.GQLType, name, e.FullName())
+		}
+		e.Requires = append(e.Requires, f)
+		return nil
+	}
+	return errors.Errorf("%s is not a field of %s", name, e.GQLType)
+}
+
+// fieldSet returns the field names given to a federation directive. Nested selections are not supported.
+func fieldSet(d *common.Directive) ([]string, error) {
+	lit, ok := d.Args.Get("fields")
+	if !ok || lit == nil {
+		return nil, errors.New("fields is required")
+	}
+	fields, _ := lit.Value(nil).(string)
+	if strings.ContainsAny(fields, "{}") {
+		return nil, errors.Errorf("nested fields are not supported in %q", fields)
+	}
+	names := strings.Fields(fields)
+	if len(names) == 0 {
+		return nil, errors.New("fields must not be empty")
+	}
+	return names, nil
+}
+
+func externalField(obj *schema.Object, name string) (*schema.Field, error) {
+	field := obj.Fields.Get(name)
+	if field == nil {
+		return nil, errors.Errorf("%s is not a field of %s", name, obj.Name)
+	}
+	if field.Directives.Get("external") == nil {
+		return nil, errors.Errorf("%s.%s must be marked @external", obj.Name, name)
+	}
+	return field, nil
+}
+
+func namedType(t common.Type) common.Type {
+	for {
+		switch val := t.(type) {
+		case *common.NonNull:
+			t = val.OfType
+		case *common.List:
+			t = val.OfType
+		default:
+			return t
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederation(t *testing.T) {
+	generateFederated := func(name string, schema string) error {
+		return Generate(Config{
+			SchemaStr:  schema,
+			Exec:       PackageConfig{Filename: "testdata/gen/" + name + "/exec.go"},
+			Model:      PackageConfig{Filename: "testdata/gen/" + name + "/model.go"},
+			Federation: true,
+		})
+	}
+
+	t.Run("without entities", func(t *testing.T) {
+		err := generateFederated("federationnoentities", `
+			type Query {
+				hello: String!
+			}
+		`)
+		require.NoError(t, err)
+	})
+
+	t.Run("key must name fields of the type", func(t *testing.T) {
+		err := generateFederated("federationkey", `
+			extend type Query {
+				users: [User!]!
+			}
+			type User @key(fields: "email") {
+				id: ID!
+			}
+		`)
+		require.EqualError(t, err, "exec plan failed: @key on User: email is not a field of User")
+	})
+
+	t.Run("nested keys are not supported", func(t *testing.T) {
+		err := generateFederated("federationnestedkey", `
+			extend type Query {
+				users: [User!]!
+			}
+			type User @key(fields: "id organization { id }") {
+				id: ID!
+			}
+		`)
+		require.EqualError(t, err, `exec plan failed: @key on User: nested fields are not supported in "id organization { id }"`)
+	})
+
+	t.Run("required fields must be external", func(t *testing.T) {
+		err := generateFederated("federationrequires", `
+			extend type Product @key(fields: "upc") {
+				upc: String! @external
+				weight: Int
+				shippingEstimate: Int @requires(fields: "weight")
+			}
+		`)
+		require.EqualError(t, err, "exec plan failed: @requires on Product.shippingEstimate: Product.weight must be marked @external")
+	})
+
+	t.Run("provided fields must be external", func(t *testing.T) {
+		err := generateFederated("federationprovides", `
+			type Review @key(fields: "id") {
+				id: ID!
+				author: User @provides(fields: "name")
+			}
+			extend type User @key(fields: "id") {
+				id: ID! @external
+			}
+		`)
+		require.EqualError(t, err, "exec plan failed: @provides on Review.author: name is not a field of User")
+	})
+}
@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"go/build"
 	"strconv"
 )
 
@@ -14,6 +15,7 @@ type Import struct {
 type Imports struct {
 	imports []*Import
 	destDir string
+	ctx     *build.Context // Used to look up package names, defaults to build.Default
 }
 
 func (i *Import) Write() string {
@@ -24,11 +24,14 @@ var ambientImports = []string{
 	"github.com/vektah/gqlgen/neelance/schema",
 	"github.com/vektah/gqlgen/neelance/validation",
 	"github.com/vektah/gqlgen/graphql",
+	"github.com/vektah/gqlgen/complexity",
+	"github.com/vektah/gqlgen/federation",
 }
 
-func buildImports(types NamedTypes, destDir string) *Imports {
+func buildImports(ctx *build.Context, types NamedTypes, destDir string) *Imports {
 	imports := Imports{
 		destDir: destDir,
+		ctx:     ctx,
 	}
 
 	for _, ambient := range ambientImports {
@@ -56,7 +59,11 @@ func (s *Imports) add(path string) *Import {
 		return existing
 	}
 
-	pkg, err := build.Default.Import(path, s.destDir, 0)
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = &build.Default
+	}
+	pkg, err := ctx.Import(path, s.destDir, 0)
 	if err != nil {
 		panic(err)
 	}
@@ -3,13 +3,15 @@ package codegen
 type Model struct {
 	*NamedType
 
-	Fields []ModelField
+	Description string
+	Fields      []ModelField
 }
 
 type ModelField struct {
 	*Type
-	GQLName   string
-	GoVarName string
-	GoFKName  string
-	GoFKType  string
+	GQLName     string
+	GoVarName   string
+	GoFKName    string
+	GoFKType    string
+	Description string
 }
@@ -13,9 +13,10 @@ func (cfg *Config) buildModels(types NamedTypes, prog *loader.Program) ([]Model,
 
 	for _, typ := range cfg.schema.Types {
 		var model Model
+		fieldDescs := map[string]string{}
 		switch typ := typ.(type) {
 		case *schema.Object:
-			obj, err := cfg.buildObject(types, typ)
+			obj, err := cfg.buildObject(types, typ, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -23,6 +24,9 @@ func (cfg *Config) buildModels(types NamedTypes, prog *loader.Program) ([]Model,
 				continue
 			}
 			model = cfg.obj2Model(obj)
+			for _, field := range typ.Fields {
+				fieldDescs[field.Name] = field.Desc
+			}
 		case *schema.InputObject:
 			obj, err := buildInput(types, typ)
 			if err != nil {
@@ -32,6 +36,9 @@ func (cfg *Config) buildModels(types NamedTypes, prog *loader.Program) ([]Model,
 				continue
 			}
 			model = cfg.obj2Model(obj)
+			for _, field := range typ.Values {
+				fieldDescs[field.Name.Name] = field.Desc
+			}
 		case *schema.Interface, *schema.Union:
 			intf := cfg.buildInterface(types, typ, prog)
 			if intf.IsUserDefined {
@@ -42,6 +49,11 @@ func (cfg *Config) buildModels(types NamedTypes, prog *loader.Program) ([]Model,
 			continue
 		}
 
+		model.Description = typ.Description()
+		for i := range model.Fields {
+			model.Fields[i].Description = fieldDescs[model.Fields[i].GQLName]
+		}
+
 		models = append(models, model)
 	}
 
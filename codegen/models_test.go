@@ -0,0 +1,43 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescriptionsAreWrittenToModels(t *testing.T) {
+	require.NoError(t, generate("descriptions", `
+		type Query {
+			todos: [Todo!]!
+		}
+
+		# A todo with a comment description
+		type Todo {
+			text: String!
+			state: State!
+		}
+
+		"Filters for todos"
+		input TodoFilter {
+			"""Only find todos containing this text"""
+			text: String
+		}
+
+		enum State {
+			OPEN
+			"""
+			all done
+			"""
+			DONE
+		}
+	`))
+
+	models, err := ioutil.ReadFile("testdata/gen/descriptions/model.go")
+	require.NoError(t, err)
+	require.Contains(t, string(models), "// A todo with a comment description\ntype Todo struct {")
+	require.Contains(t, string(models), "// Filters for todos\ntype TodoFilter struct {")
+	require.Contains(t, string(models), "\t// Only find todos containing this text\n\tText *string")
+	require.Contains(t, string(models), "\t// all done\n\tStateDone State")
+}
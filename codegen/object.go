@@ -7,6 +7,8 @@ import (
 	"strings"
 	"text/template"
 	"unicode"
+
+	"github.com/vektah/gqlgen/codegen/templates"
 )
 
 type Object struct {
@@ -26,8 +28,12 @@ type Field struct {
 	GoMethodName  string          // The name of the method in go, if any
 	GoVarName     string          // The name of the var in go, if any
 	Args          []FieldArgument // A list of arguments to be passed to this field
+	Directives    []Directive     // A list of directives to call around the resolver
+	Complexity    int             // The configured cost of this field, if any
+	Multipliers   []string        // Arguments that multiply the complexity of this fields selections
 	ForceResolver bool            // Should be emit Resolver method
 	NoErr         bool            // If this is bound to a go method, does that method have an error as the second argument
+	HasContext    bool            // If this is bound to a go method, does that method take a context as the first argument
 	Object        *Object         // A link back to the parent object
 	Default       interface{}     // The default value
 }
@@ -115,12 +121,12 @@ func (f *Field) ResolverDeclaration() string {
 func (f *Field) CallArgs() string {
 	var args []string
 
-	if f.GoMethodName == "" {
+	if f.GoMethodName == "" || f.HasContext {
 		args = append(args, "ctx")
+	}
 
-		if !f.Object.Root {
-			args = append(args, "obj")
-		}
+	if f.GoMethodName == "" && !f.Object.Root {
+		args = append(args, "obj")
 	}
 
 	for _, arg := range f.Args {
@@ -130,6 +136,42 @@ func (f *Field) CallArgs() string {
 	return strings.Join(args, ", ")
 }
 
+func (f *Field) HasComplexity() bool {
+	return f.Complexity != 0 || len(f.Multipliers) > 0
+}
+
+// ComplexityCost is the fixed cost of the field when calculating complexity, defaulting to 1
+func (f *Field) ComplexityCost() int {
+	if f.Complexity == 0 {
+		return 1
+	}
+	return f.Complexity
+}
+
+// ResolverCall is the return statement that fetches the value of the field
+func (f *Field) ResolverCall() string {
+	switch {
+	case f.IsResolver():
+		return fmt.Sprintf("return ec.resolvers.%s_%s(%s)", f.Object.GQLType, f.GQLName, f.CallArgs())
+	case f.GoVarName != "":
+		return fmt.Sprintf("return obj.%s, nil", f.GoVarName)
+	case f.NoErr:
+		return fmt.Sprintf("return %s(%s), nil", f.GoMethodName, f.CallArgs())
+	default:
+		return fmt.Sprintf("return %s(%s)", f.GoMethodName, f.CallArgs())
+	}
+}
+
+// WrapDirectives nests the given resolver body inside calls to each of the fields directives, the first directive
+// in the schema becomes the outermost call.
+func (f *Field) WrapDirectives(body string) string {
+	for i := len(f.Directives) - 1; i >= 0; i-- {
+		d := f.Directives[i]
+		body = fmt.Sprintf("return %s(ctx, func(ctx context.Context) (interface{}, error) {\n%s\n}, %s)", d.FullName(), body, templates.Dump(d.Args))
+	}
+	return body
+}
+
 // should be in the template, but its recursive and has a bunch of args
 func (f *Field) WriteJson() string {
 	return f.doWriteJson("res", f.Type.Modifiers, false, 1)
@@ -204,3 +246,13 @@ func ucFirst(s string) string {
 	r[0] = unicode.ToUpper(r[0])
 	return string(r)
 }
+
+func lcFirst(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
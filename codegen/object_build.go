@@ -10,13 +10,13 @@ import (
 	"golang.org/x/tools/go/loader"
 )
 
-func (cfg *Config) buildObjects(types NamedTypes, prog *loader.Program, imports *Imports) (Objects, error) {
+func (cfg *Config) buildObjects(types NamedTypes, prog *loader.Program, imports *Imports, directives map[string]*Ref) (Objects, error) {
 	var objects Objects
 
 	for _, typ := range cfg.schema.Types {
 		switch typ := typ.(type) {
 		case *schema.Object:
-			obj, err := cfg.buildObject(types, typ)
+			obj, err := cfg.buildObject(types, typ, directives)
 			if err != nil {
 				return nil, err
 			}
@@ -80,7 +80,7 @@ func sanitizeGoName(name string) string {
 	return name
 }
 
-func (cfg *Config) buildObject(types NamedTypes, typ *schema.Object) (*Object, error) {
+func (cfg *Config) buildObject(types NamedTypes, typ *schema.Object, directives map[string]*Ref) (*Object, error) {
 	obj := &Object{NamedType: types[typ.TypeName()]}
 	typeEntry, entryExists := cfg.Models[typ.TypeName()]
 
@@ -90,11 +90,9 @@ func (cfg *Config) buildObject(types NamedTypes, typ *schema.Object) (*Object, e
 
 	for _, field := range typ.Fields {
 
-		var forceResolver bool
+		var typeField TypeMapField
 		if entryExists {
-			if typeField, ok := typeEntry.Fields[field.Name]; ok {
-				forceResolver = typeField.Resolver
-			}
+			typeField = typeEntry.Fields[field.Name]
 		}
 
 		var args []FieldArgument
@@ -117,12 +115,21 @@ func (cfg *Config) buildObject(types NamedTypes, typ *schema.Object) (*Object, e
 			args = append(args, newArg)
 		}
 
+		for _, multiplier := range typeField.Multipliers {
+			if field.Args.Get(multiplier) == nil {
+				return nil, errors.Errorf("complexity multiplier %s is not an argument of %s.%s", multiplier, obj.GQLType, field.Name)
+			}
+		}
+
 		obj.Fields = append(obj.Fields, Field{
 			GQLName:       field.Name,
 			Type:          types.getType(field.Type),
 			Args:          args,
+			Directives:    fieldDirectives(directives, field.Directives),
 			Object:        obj,
-			ForceResolver: forceResolver,
+			ForceResolver: typeField.Resolver,
+			Complexity:    typeField.Complexity,
+			Multipliers:   typeField.Multipliers,
 		})
 	}
 
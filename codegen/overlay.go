@@ -0,0 +1,188 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// overlay holds the generated files in memory until generation has succeeded. The build context it provides sees
+// them as if they had already been written, and stale files as if they had already been removed, so the packages
+// can be loaded and type checked without touching the disk.
+type overlay struct {
+	files   map[string][]byte // Generated files, by absolute filename
+	removed map[string]bool   // Previously generated files that are hidden until they are replaced or removed
+}
+
+func newOverlay() *overlay {
+	return &overlay{
+		files:   map[string][]byte{},
+		removed: map[string]bool{},
+	}
+}
+
+// write formats a generated file and adds it to the overlay
+func (o *overlay) write(filename string, b []byte) {
+	formatted, err := gofmt(filename, b)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gofmt failed: %s\n", err.Error())
+	}
+	o.files[filename] = formatted
+}
+
+// remove hides a file, it is removed from disk on commit unless it has been generated again
+func (o *overlay) remove(filename string) {
+	o.removed[filename] = true
+	delete(o.files, filename)
+}
+
+// stale lists the files that commit would write or remove
+func (o *overlay) stale() ([]string, error) {
+	var stale []string
+	for filename, b := range o.files {
+		existing, err := ioutil.ReadFile(filename)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "unable to read %s", filename)
+		}
+		if err != nil || !bytes.Equal(existing, b) {
+			stale = append(stale, filename)
+		}
+	}
+
+	for filename := range o.removed {
+		if _, generated := o.files[filename]; generated {
+			continue
+		}
+		if _, err := os.Stat(filename); err == nil {
+			stale = append(stale, filename)
+		}
+	}
+
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// commit writes every file that has changed and removes the stale ones. Files are replaced atomically, so a
+// partially written file is never left behind.
+func (o *overlay) commit() error {
+	stale, err := o.stale()
+	if err != nil {
+		return err
+	}
+
+	for _, filename := range stale {
+		b, generated := o.files[filename]
+		if !generated {
+			if err := os.Remove(filename); err != nil {
+				return errors.Wrapf(err, "failed to remove %s", filename)
+			}
+			continue
+		}
+
+		if err := writeAtomic(filename, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeAtomic(filename string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename))
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", filename)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", filename)
+	}
+	return nil
+}
+
+// context is a copy of the default build context that reads from the overlay before the disk
+func (o *overlay) context() *build.Context {
+	ctx := build.Default
+
+	ctx.OpenFile = func(path string) (io.ReadCloser, error) {
+		if b, ok := o.files[path]; ok {
+			return ioutil.NopCloser(bytes.NewReader(b)), nil
+		}
+		if o.removed[path] {
+			return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+		}
+		return os.Open(path)
+	}
+
+	ctx.IsDir = func(path string) bool {
+		for filename := range o.files {
+			if dir := filepath.Dir(filename); dir == path || hasDirPrefix(dir, path) {
+				return true
+			}
+		}
+		info, err := os.Stat(path)
+		return err == nil && info.IsDir()
+	}
+
+	ctx.ReadDir = func(dir string) ([]os.FileInfo, error) {
+		var infos []os.FileInfo
+		onDisk, err := ioutil.ReadDir(dir)
+		if err != nil && !ctx.IsDir(dir) {
+			return nil, err
+		}
+		for _, info := range onDisk {
+			filename := filepath.Join(dir, info.Name())
+			if _, generated := o.files[filename]; !generated && !o.removed[filename] {
+				infos = append(infos, info)
+			}
+		}
+		for filename, b := range o.files {
+			if filepath.Dir(filename) == dir {
+				infos = append(infos, overlayFileInfo{name: filepath.Base(filename), size: int64(len(b))})
+			}
+		}
+		sort.Slice(infos, func(i, j int) bool {
+			return infos[i].Name() < infos[j].Name()
+		})
+		return infos, nil
+	}
+
+	return &ctx
+}
+
+func hasDirPrefix(path string, prefix string) bool {
+	return len(path) > len(prefix) && path[:len(prefix)] == prefix && os.IsPathSeparator(path[len(prefix)])
+}
+
+type overlayFileInfo struct {
+	name string
+	size int64
+}
+
+func (f overlayFileInfo) Name() string       { return f.name }
+func (f overlayFileInfo) Size() int64        { return f.size }
+func (f overlayFileInfo) Mode() os.FileMode  { return 0644 }
+func (f overlayFileInfo) ModTime() time.Time { return time.Time{} }
+func (f overlayFileInfo) IsDir() bool        { return false }
+func (f overlayFileInfo) Sys() interface{}   { return nil }
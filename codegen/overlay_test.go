@@ -0,0 +1,72 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalGeneration(t *testing.T) {
+	dir := "testdata/gen/incremental"
+	require.NoError(t, os.RemoveAll(dir))
+
+	cfg := func(schema string) Config {
+		return Config{
+			SchemaStr: schema,
+			Exec:      PackageConfig{Filename: dir + "/exec.go"},
+			Model:     PackageConfig{Filename: dir + "/model.go"},
+		}
+	}
+	schema := `type Query { user: User } type User { name: String! }`
+
+	stale, err := Stale(cfg(schema))
+	require.NoError(t, err)
+	require.Len(t, stale, 2)
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err), "checking should not write anything")
+
+	require.NoError(t, Generate(cfg(schema)))
+	stale, err = Stale(cfg(schema))
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	t.Run("unchanged files are not written", func(t *testing.T) {
+		old := time.Now().Add(-time.Hour).Truncate(time.Second)
+		require.NoError(t, os.Chtimes(dir+"/exec.go", old, old))
+
+		require.NoError(t, Generate(cfg(schema)))
+
+		info, err := os.Stat(dir + "/exec.go")
+		require.NoError(t, err)
+		require.True(t, info.ModTime().Equal(old))
+	})
+
+	t.Run("a failed run leaves the previous output", func(t *testing.T) {
+		before, err := ioutil.ReadFile(dir + "/exec.go")
+		require.NoError(t, err)
+
+		invalid := cfg(`type Query { user: User } type User { name: String! }`)
+		invalid.Models = TypeMap{"User": {Model: "github.com/vektah/gqlgen/codegen/" + dir + ".Missing"}}
+		require.Error(t, Generate(invalid))
+
+		after, err := ioutil.ReadFile(dir + "/exec.go")
+		require.NoError(t, err)
+		require.Equal(t, string(before), string(after))
+		_, err = os.Stat(dir + "/model.go")
+		require.NoError(t, err)
+	})
+
+	t.Run("stale models are removed", func(t *testing.T) {
+		noModels := `type Query { name: String! }`
+		stale, err := Stale(cfg(noModels))
+		require.NoError(t, err)
+		require.Equal(t, []string{abs(dir + "/exec.go"), abs(dir + "/model.go")}, stale)
+
+		require.NoError(t, Generate(cfg(noModels)))
+		_, err = os.Stat(dir + "/model.go")
+		require.True(t, os.IsNotExist(err))
+	})
+}
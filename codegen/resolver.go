@@ -0,0 +1,67 @@
+package codegen
+
+type ResolverBuild struct {
+	PackageName string
+	Imports     []*Import
+	Type        string // The name of the root resolver struct
+	TypeExists  bool
+	ExecPackage string // The alias of the exec package, empty when the resolver is in the same package
+	Objects     []*ResolverObject
+
+	// Append is set when the file already exists, only the missing declarations are rendered so they can be added to
+	// the end of it.
+	Append bool
+}
+
+// ResolverObject is one of the resolvers returned by ResolverRoot, eg QueryResolver.
+type ResolverObject struct {
+	Name             string // The name of the method on ResolverRoot
+	Exists           bool   // The struct implementing it has already been declared
+	RootMethodExists bool   // The root resolver already returns it
+	Methods          []*ResolverMethod
+}
+
+type ResolverMethod struct {
+	Name        string
+	Declaration string
+	Exists      bool
+}
+
+// Exec qualifies a type declared in the exec package
+func (b *ResolverBuild) Exec(name string) string {
+	if b.ExecPackage == "" {
+		return name
+	}
+	return b.ExecPackage + "." + name
+}
+
+// Missing is true if anything still needs to be written
+func (b *ResolverBuild) Missing() bool {
+	if !b.TypeExists {
+		return true
+	}
+	for _, o := range b.Objects {
+		if o.Missing() {
+			return true
+		}
+	}
+	return false
+}
+
+// StructName is the unexported struct implementing the object's resolver, eg queryResolver
+func (o *ResolverObject) StructName() string {
+	return lcFirst(o.Name) + "Resolver"
+}
+
+// Missing is true if anything about this object still needs to be written
+func (o *ResolverObject) Missing() bool {
+	if !o.Exists || !o.RootMethodExists {
+		return true
+	}
+	for _, m := range o.Methods {
+		if !m.Exists {
+			return true
+		}
+	}
+	return false
+}
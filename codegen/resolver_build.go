@@ -0,0 +1,190 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *ResolverConfig) normalize() error {
+	if c.Type == "" {
+		c.Type = "Resolver"
+	}
+	return c.PackageConfig.normalize()
+}
+
+// resolver plans the resolver stubs. Only the declarations missing from the resolver package are planned, so that
+// implementations are never overwritten, even when they have been moved out of the generated file.
+func (cfg *Config) resolver() (*ResolverBuild, error) {
+	build, err := cfg.bindPackage(cfg.Resolver.PackageConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := cfg.buildContext()
+	existing, err := parseDeclarations(ctx, cfg.Resolver.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	b := &ResolverBuild{
+		PackageName: cfg.Resolver.Package,
+		Imports:     build.Imports,
+		Type:        cfg.Resolver.Type,
+		Append:      fileExists(ctx, cfg.Resolver.Filename),
+	}
+	b.TypeExists = existing.hasType(b.Type)
+	for _, imp := range build.Imports {
+		if imp.Path == cfg.Exec.ImportPath() {
+			b.ExecPackage = imp.Alias()
+		}
+	}
+
+	for _, object := range build.Objects {
+		if !object.HasResolvers() {
+			continue
+		}
+		var declarations []string
+		for _, field := range object.Fields {
+			if field.IsResolver() {
+				declarations = append(declarations, field.ShortResolverDeclaration())
+			}
+		}
+		b.Objects = append(b.Objects, existing.object(b.Type, object.GQLType, declarations))
+	}
+
+	if len(build.Entities) > 0 {
+		var declarations []string
+		for _, entity := range build.Entities {
+			for _, key := range entity.Keys {
+				declarations = append(declarations, key.ShortResolverDeclaration())
+			}
+		}
+		b.Objects = append(b.Objects, existing.object(b.Type, "Entity", declarations))
+	}
+
+	return b, nil
+}
+
+// declarations are the types and methods already declared in a package
+type declarations struct {
+	types   map[string]bool
+	methods map[string]map[string]bool // receiver type -> method names
+}
+
+// parseDeclarations collects the declarations from every go file in dir, other than tests. Files are read through
+// ctx so generated files that have not been written yet are seen too.
+func parseDeclarations(ctx *build.Context, dir string) (*declarations, error) {
+	d := &declarations{types: map[string]bool{}, methods: map[string]map[string]bool{}}
+
+	infos, err := readDir(ctx, dir)
+	if os.IsNotExist(err) {
+		return d, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "unable to read resolver package")
+	}
+
+	fset := token.NewFileSet()
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".go") || strings.HasSuffix(info.Name(), "_test.go") {
+			continue
+		}
+
+		filename := filepath.Join(dir, info.Name())
+		src, err := readFile(ctx, filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read %s", filename)
+		}
+		file, err := parser.ParseFile(fset, filename, src, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse existing resolver")
+		}
+		d.add(file)
+	}
+	return d, nil
+}
+
+func (d *declarations) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					d.types[typeSpec.Name.Name] = true
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) == 0 {
+				continue
+			}
+			recv := decl.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				if d.methods[ident.Name] == nil {
+					d.methods[ident.Name] = map[string]bool{}
+				}
+				d.methods[ident.Name][decl.Name.Name] = true
+			}
+		}
+	}
+}
+
+// readDir, readFile and fileExists fall back to the disk when ctx does not override it, like go/build does
+func readDir(ctx *build.Context, dir string) ([]os.FileInfo, error) {
+	if ctx.ReadDir != nil {
+		return ctx.ReadDir(dir)
+	}
+	return ioutil.ReadDir(dir)
+}
+
+func readFile(ctx *build.Context, filename string) ([]byte, error) {
+	var f io.ReadCloser
+	var err error
+	if ctx.OpenFile != nil {
+		f, err = ctx.OpenFile(filename)
+	} else {
+		f, err = os.Open(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
+}
+
+func fileExists(ctx *build.Context, filename string) bool {
+	_, err := readFile(ctx, filename)
+	return err == nil
+}
+
+func (d *declarations) hasType(name string) bool {
+	return d.types[name]
+}
+
+// object marks which parts of an object's resolver are already declared
+func (d *declarations) object(rootType string, name string, methodDeclarations []string) *ResolverObject {
+	o := &ResolverObject{Name: name}
+	for _, declaration := range methodDeclarations {
+		o.Methods = append(o.Methods, &ResolverMethod{
+			Name:        declaration[:strings.Index(declaration, "(")],
+			Declaration: declaration,
+		})
+	}
+
+	o.Exists = d.hasType(o.StructName())
+	o.RootMethodExists = d.methods[rootType][name]
+	for _, m := range o.Methods {
+		m.Exists = d.methods[o.StructName()][m.Name]
+	}
+	return o
+}
@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/loader"
+)
+
+const resolverSchema = `
+	type Query {
+		user(id: ID!): User
+	}
+	type Mutation {
+		rename(id: ID!, name: String!): User!
+	}
+	type User {
+		id: ID!
+		name: String!
+	}
+`
+
+func TestGenerateResolver(t *testing.T) {
+	filename := "testdata/gen/resolver/resolver.go"
+	require.NoError(t, os.RemoveAll("testdata/gen/resolver"))
+	require.NoError(t, generateResolver("resolver", resolverSchema, ResolverConfig{}))
+
+	b, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	generated := string(b)
+	require.Contains(t, generated, "type Resolver struct{}")
+	require.Contains(t, generated, "func (r *Resolver) Query() QueryResolver {")
+	require.Contains(t, generated, "func (r *queryResolver) User(ctx context.Context, id string) (*User, error) {")
+	require.Contains(t, generated, "func (r *mutationResolver) Rename(ctx context.Context, id string, name string) (User, error) {")
+
+	t.Run("existing implementations are kept", func(t *testing.T) {
+		implemented := strings.Replace(generated,
+			"func (r *queryResolver) User(ctx context.Context, id string) (*User, error) {\n\tpanic(\"not implemented\")",
+			"func (r *queryResolver) User(ctx context.Context, id string) (*User, error) {\n\treturn &User{ID: id}, nil",
+			1,
+		)
+		require.NotEqual(t, generated, implemented)
+		require.NoError(t, ioutil.WriteFile(filename, []byte(implemented), 0644))
+
+		schema := strings.Replace(resolverSchema, "user(id: ID!): User", "user(id: ID!): User\n\t\tusers(first: Int): [User!]!", 1)
+		schema += "type Subscription { renamed: User! }"
+		require.NoError(t, generateResolver("resolver", schema, ResolverConfig{}))
+
+		b, err := ioutil.ReadFile(filename)
+		require.NoError(t, err)
+		updated := string(b)
+		require.True(t, strings.HasPrefix(updated, implemented), "the existing file should be unchanged")
+		require.Contains(t, updated, "func (r *queryResolver) Users(ctx context.Context, first *int) ([]User, error) {")
+		require.Contains(t, updated, "func (r *Resolver) Subscription() SubscriptionResolver {")
+		require.Contains(t, updated, "func (r *subscriptionResolver) Renamed(ctx context.Context) (<-chan User, error) {")
+		require.Equal(t, 1, strings.Count(updated, "func (r *queryResolver) User("))
+
+		require.NoError(t, generateResolver("resolver", schema, ResolverConfig{}))
+		b, err = ioutil.ReadFile(filename)
+		require.NoError(t, err)
+		require.Equal(t, updated, string(b), "nothing should change when nothing is missing")
+	})
+
+	t.Run("implementations in other files are kept", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll("testdata/gen/resolver_files"))
+		require.NoError(t, generateResolver("resolver_files", resolverSchema, ResolverConfig{}))
+
+		filename := "testdata/gen/resolver_files/resolver.go"
+		b, err := ioutil.ReadFile(filename)
+		require.NoError(t, err)
+		stub := "\nfunc (r *queryResolver) User(ctx context.Context, id string) (*User, error) {\n\tpanic(\"not implemented\")\n}\n"
+		require.Contains(t, string(b), stub)
+		require.NoError(t, ioutil.WriteFile(filename, []byte(strings.Replace(string(b), stub, "", 1)), 0644))
+
+		user := "package resolver_files\n\nimport \"context\"\n\n" +
+			"func (r *queryResolver) User(ctx context.Context, id string) (*User, error) {\n\treturn &User{ID: id}, nil\n}\n"
+		require.NoError(t, ioutil.WriteFile("testdata/gen/resolver_files/user.go", []byte(user), 0644))
+
+		require.NoError(t, generateResolver("resolver_files", resolverSchema, ResolverConfig{}))
+		b, err = ioutil.ReadFile(filename)
+		require.NoError(t, err)
+		require.NotContains(t, string(b), "func (r *queryResolver) User(")
+	})
+
+	t.Run("resolvers in another package", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll("testdata/gen/resolver_pkg"))
+		err := generateResolver("resolver_pkg", resolverSchema, ResolverConfig{
+			PackageConfig: PackageConfig{Filename: "testdata/gen/resolver_pkg/resolvers/resolver.go"},
+			Type:          "Root",
+		})
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadFile("testdata/gen/resolver_pkg/resolvers/resolver.go")
+		require.NoError(t, err)
+		generated := string(b)
+		require.Contains(t, generated, "package resolvers")
+		require.Contains(t, generated, "func (r *Root) Query() resolver_pkg.QueryResolver {")
+		require.Contains(t, generated, "func (r *queryResolver) User(ctx context.Context, id string) (*resolver_pkg.User, error) {")
+	})
+}
+
+// generateResolver generates the schema into testdata/gen/name, then checks the resolver implements ResolverRoot.
+func generateResolver(name string, schema string, resolver ResolverConfig) error {
+	dir := "testdata/gen/" + name
+	if resolver.Filename == "" {
+		resolver.Filename = dir + "/resolver.go"
+	}
+
+	cfg := Config{
+		SchemaStr: schema,
+		Exec:      PackageConfig{Filename: dir + "/exec.go"},
+		Model:     PackageConfig{Filename: dir + "/model.go"},
+		Resolver:  resolver,
+	}
+	if err := Generate(cfg); err != nil {
+		return err
+	}
+
+	resolverType := resolver.Type
+	if resolverType == "" {
+		resolverType = "Resolver"
+	}
+	if err := cfg.Resolver.normalize(); err != nil {
+		return err
+	}
+	check := "package " + cfg.Resolver.Package + "\n\nimport gen \"github.com/vektah/gqlgen/codegen/" + dir + "\"\n\n" +
+		"var _ gen.ResolverRoot = &" + resolverType + "{}\n"
+	checkDir := cfg.Resolver.Dir()
+	if err := ioutil.WriteFile(checkDir+"/check_test.go", []byte(check), 0644); err != nil {
+		return err
+	}
+
+	conf := loader.Config{}
+	conf.ImportWithTests(cfg.Resolver.ImportPath())
+	_, err := conf.Load()
+	return err
+}
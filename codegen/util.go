@@ -19,12 +19,16 @@ func findGoType(prog *loader.Program, pkgName string, typeName string) (types.Ob
 		fullName = pkgName + "." + typeName
 	}
 
-	pkgName, err := resolvePkg(pkgName)
-	if err != nil {
-		return nil, errors.Errorf("unable to resolve package for %s: %s\n", fullName, err.Error())
-	}
-
+	// packages that have only been generated in memory so far can't be resolved, but they are always imported by
+	// their full path
 	pkg := prog.Imported[pkgName]
+	if pkg == nil {
+		resolved, err := resolvePkg(pkgName)
+		if err != nil {
+			return nil, errors.Errorf("unable to resolve package for %s: %s\n", fullName, err.Error())
+		}
+		pkg = prog.Imported[resolved]
+	}
 	if pkg == nil {
 		return nil, errors.Errorf("required package was not loaded: %s", fullName)
 	}
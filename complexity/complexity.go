@@ -0,0 +1,193 @@
+package complexity
+
+import (
+	"encoding/json"
+
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// FieldFunc returns the complexity of a single field, given the combined complexity of its selections and the
+// arguments it was called with. Returning false falls back to the default of 1 + childComplexity.
+type FieldFunc func(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool)
+
+// Estimator is implemented by executable schemas that have field complexities configured in gqlgen.yml.
+type Estimator interface {
+	Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool)
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// Calculate walks the selections of an operation against the schema and returns its total complexity. The operation
+// must already have been validated. Every field costs 1 plus the complexity of its selections unless fieldFunc
+// says otherwise. Abstract types cost as much as their most expensive possible type.
+func Calculate(s *schema.Schema, doc *query.Document, op *query.Operation, variables map[string]interface{}, fieldFunc FieldFunc) int {
+	var entryPoint string
+	switch op.Type {
+	case query.Query:
+		entryPoint = "query"
+	case query.Mutation:
+		entryPoint = "mutation"
+	case query.Subscription:
+		entryPoint = "subscription"
+	}
+
+	root, ok := s.EntryPoints[entryPoint].(*schema.Object)
+	if !ok {
+		return 0
+	}
+
+	w := walker{schema: s, doc: doc, variables: variables, fieldFunc: fieldFunc}
+	return w.selectionSetComplexity(root, op.Selections)
+}
+
+type walker struct {
+	schema    *schema.Schema
+	doc       *query.Document
+	variables map[string]interface{}
+	fieldFunc FieldFunc
+}
+
+func (w walker) selectionSetComplexity(t schema.NamedType, selections []query.Selection) int {
+	switch t := t.(type) {
+	case *schema.Object:
+		return w.objectComplexity(t, selections)
+	case *schema.Interface:
+		return w.maxComplexity(t.PossibleTypes, selections)
+	case *schema.Union:
+		return w.maxComplexity(t.PossibleTypes, selections)
+	default:
+		return 0
+	}
+}
+
+func (w walker) maxComplexity(possibleTypes []*schema.Object, selections []query.Selection) int {
+	max := 0
+	for _, obj := range possibleTypes {
+		if c := w.objectComplexity(obj, selections); c > max {
+			max = c
+		}
+	}
+	return max
+}
+
+func (w walker) objectComplexity(obj *schema.Object, selections []query.Selection) int {
+	satisfies := []string{obj.Name}
+	for _, i := range obj.Interfaces {
+		satisfies = append(satisfies, i.Name)
+	}
+
+	total := 0
+	for _, field := range graphql.CollectFields(w.doc, selections, satisfies, w.variables) {
+		total = safeAdd(total, w.fieldComplexity(obj, field))
+	}
+	return total
+}
+
+func (w walker) fieldComplexity(obj *schema.Object, field graphql.CollectedField) int {
+	var fieldType common.Type
+	args := field.Args
+
+	switch field.Name {
+	case "__typename":
+		return 0
+	case "__schema":
+		fieldType = w.schema.Types["__Schema"]
+	case "__type":
+		fieldType = w.schema.Types["__Type"]
+	default:
+		def := obj.Fields.Get(field.Name)
+		if def == nil {
+			return 0
+		}
+		fieldType = def.Type
+		args = argsWithDefaults(def.Args, field.Args)
+	}
+
+	childComplexity := 0
+	if len(field.Selections) > 0 {
+		childComplexity = w.selectionSetComplexity(namedType(fieldType), field.Selections)
+	}
+
+	if w.fieldFunc != nil {
+		if c, ok := w.fieldFunc(obj.Name, field.Name, childComplexity, args); ok {
+			return c
+		}
+	}
+
+	return safeAdd(1, childComplexity)
+}
+
+func argsWithDefaults(defs common.InputValueList, args map[string]interface{}) map[string]interface{} {
+	withDefaults := map[string]interface{}{}
+	for _, def := range defs {
+		if def.Default != nil {
+			withDefaults[def.Name.Name] = def.Default.Value(nil)
+		}
+	}
+	for name, value := range args {
+		withDefaults[name] = value
+	}
+	return withDefaults
+}
+
+func namedType(t common.Type) schema.NamedType {
+	for {
+		switch val := t.(type) {
+		case *common.NonNull:
+			t = val.OfType
+		case *common.List:
+			t = val.OfType
+		case schema.NamedType:
+			return val
+		default:
+			return nil
+		}
+	}
+}
+
+// Field is a helper for field complexities with a fixed cost, where the complexity of the selections is multiplied
+// by the value of each of the named arguments, eg the page size in a `first` or `limit` argument. Arguments that are
+// missing or not numbers do not change the result.
+func Field(cost int, childComplexity int, args map[string]interface{}, multipliers ...string) int {
+	for _, name := range multipliers {
+		if n, ok := intArg(args[name]); ok && n >= 0 {
+			childComplexity = safeMul(childComplexity, n)
+		}
+	}
+	return safeAdd(cost, childComplexity)
+}
+
+func intArg(val interface{}) (int, bool) {
+	switch val := val.(type) {
+	case int:
+		return val, true
+	case int32:
+		return int(val), true
+	case int64:
+		return int(val), true
+	case float64:
+		return int(val), true
+	case json.Number:
+		n, err := val.Int64()
+		return int(n), err == nil
+	default:
+		return 0, false
+	}
+}
+
+func safeAdd(a, b int) int {
+	if a > maxInt-b {
+		return maxInt
+	}
+	return a + b
+}
+
+func safeMul(a, b int) int {
+	if a != 0 && b > maxInt/a {
+		return maxInt
+	}
+	return a * b
+}
@@ -0,0 +1,86 @@
+package complexity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+var testSchema = schema.MustParse(`
+	schema { query: Query }
+	interface Named { name: String! }
+	type Query {
+		user: User
+		users(first: Int = 10): [User!]!
+		node: Node
+	}
+	type User implements Named {
+		name: String!
+		friends(first: Int): [User!]!
+	}
+	type Pet implements Named {
+		name: String!
+		owner: User!
+		toys: [String!]!
+	}
+	union Node = User | Pet
+`)
+
+func calculate(t *testing.T, q string, variables map[string]interface{}, fieldFunc FieldFunc) int {
+	doc, err := query.Parse(q)
+	require.Nil(t, err)
+
+	return Calculate(testSchema, doc, doc.Operations[0], variables, fieldFunc)
+}
+
+func TestCalculate(t *testing.T) {
+	t.Run("scalar fields", func(t *testing.T) {
+		require.Equal(t, 2, calculate(t, `{ user { name } }`, nil, nil))
+	})
+
+	t.Run("typename is free", func(t *testing.T) {
+		require.Equal(t, 2, calculate(t, `{ user { __typename name } }`, nil, nil))
+	})
+
+	t.Run("nested fields", func(t *testing.T) {
+		require.Equal(t, 4, calculate(t, `{ user { name friends { name } } }`, nil, nil))
+	})
+
+	t.Run("fragments", func(t *testing.T) {
+		q := `{ user { ...F } } fragment F on User { name friends { name } }`
+		require.Equal(t, 4, calculate(t, q, nil, nil))
+	})
+
+	t.Run("skipped fields", func(t *testing.T) {
+		require.Equal(t, 2, calculate(t, `{ user { name friends @skip(if: true) { name } } }`, nil, nil))
+	})
+
+	t.Run("unions use the most expensive type", func(t *testing.T) {
+		q := `{ node { ... on User { name } ... on Pet { name owner { name } } } }`
+		require.Equal(t, 4, calculate(t, q, nil, nil))
+	})
+
+	t.Run("field func", func(t *testing.T) {
+		q := `query($n: Int) { users { name } user { friends(first: $n) { name } } }`
+		fieldFunc := func(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+			switch typeName + "." + fieldName {
+			case "Query.users":
+				return Field(1, childComplexity, args, "first"), true
+			case "User.friends":
+				return Field(2, childComplexity, args, "first"), true
+			}
+			return 0, false
+		}
+		// users: 1 + 10 * 1, user: 1 + (2 + 5 * 1)
+		require.Equal(t, 19, calculate(t, q, map[string]interface{}{"n": float64(5)}, fieldFunc))
+	})
+}
+
+func TestField(t *testing.T) {
+	require.Equal(t, 21, Field(1, 2, map[string]interface{}{"first": 10}, "first"))
+	require.Equal(t, 3, Field(1, 2, map[string]interface{}{}, "first"))
+	require.Equal(t, 3, Field(1, 2, map[string]interface{}{"first": "ten"}, "first"))
+	require.Equal(t, maxInt, Field(1, 2, map[string]interface{}{"first": maxInt}, "first"))
+}
@@ -0,0 +1,57 @@
+package complexity
+
+import (
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// Depth returns the deepest level of nested fields in an operation, following fragment spreads. Fragments do not add
+// a level of their own, so `{ a { ...F } } fragment F on A { b }` has a depth of 2. Unlike Calculate no schema is
+// needed, so fields that would be skipped by type conditions or directives are still counted.
+func Depth(doc *query.Document, op *query.Operation) int {
+	d := depthWalker{doc: doc, fragments: map[string]int{}, inProgress: map[string]bool{}}
+	return d.selectionSetDepth(op.Selections)
+}
+
+type depthWalker struct {
+	doc        *query.Document
+	fragments  map[string]int  // The depth of each fragment that has been walked, so reused fragments are walked once
+	inProgress map[string]bool // The fragments being walked, spreading one of them again would be a cycle
+}
+
+func (d *depthWalker) selectionSetDepth(selections []query.Selection) int {
+	max := 0
+	for _, sel := range selections {
+		var depth int
+		switch sel := sel.(type) {
+		case *query.Field:
+			depth = 1 + d.selectionSetDepth(sel.Selections)
+		case *query.InlineFragment:
+			depth = d.selectionSetDepth(sel.Selections)
+		case *query.FragmentSpread:
+			depth = d.fragmentDepth(sel.Name.Name)
+		}
+
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
+
+func (d *depthWalker) fragmentDepth(name string) int {
+	if depth, ok := d.fragments[name]; ok {
+		return depth
+	}
+
+	fragment := d.doc.Fragments.Get(name)
+	if fragment == nil || d.inProgress[name] {
+		return 0
+	}
+
+	d.inProgress[name] = true
+	depth := d.selectionSetDepth(fragment.Selections)
+	delete(d.inProgress, name)
+
+	d.fragments[name] = depth
+	return depth
+}
@@ -0,0 +1,62 @@
+package complexity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+func depth(t *testing.T, q string) int {
+	doc, err := query.Parse(q)
+	require.Nil(t, err)
+
+	return Depth(doc, doc.Operations[0])
+}
+
+func TestDepth(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		require.Equal(t, 1, depth(t, `{ a b }`))
+		require.Equal(t, 3, depth(t, `{ a { b { c } } d }`))
+	})
+
+	t.Run("inline fragments", func(t *testing.T) {
+		require.Equal(t, 2, depth(t, `{ a { ... on A { b } } }`))
+	})
+
+	t.Run("fragment spreads", func(t *testing.T) {
+		require.Equal(t, 4, depth(t, `{ a { ...F } } fragment F on A { b { c { d } } }`))
+	})
+
+	t.Run("fragments used more than once", func(t *testing.T) {
+		require.Equal(t, 3, depth(t, `{ a { ...F } b { c { ...F } } } fragment F on A { e }`))
+		require.Equal(t, 4, depth(t, `{ a { ...F } b { c { ...F } } } fragment F on A { e { f } }`))
+	})
+}
+
+func TestDepthReusedFragments(t *testing.T) {
+	// each fragment spreads the next one twice, walking every spread would take 2^40 steps
+	q := `{ ...F0 }`
+	for i := 0; i < 40; i++ {
+		q += fmt.Sprintf(` fragment F%d on A { a { ...F%d ...F%d } }`, i, i+1, i+1)
+	}
+	q += ` fragment F40 on A { b }`
+
+	done := make(chan int)
+	go func() {
+		done <- depth(t, q)
+	}()
+
+	select {
+	case d := <-done:
+		require.Equal(t, 41, d)
+	case <-time.After(5 * time.Second):
+		t.Fatal("depth took too long, fragments are being walked more than once")
+	}
+}
+
+func TestDepthFragmentCycles(t *testing.T) {
+	require.Equal(t, 2, depth(t, `{ ...A } fragment A on T { a { ...B } } fragment B on T { b { ...A } }`))
+}
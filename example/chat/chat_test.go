@@ -2,15 +2,19 @@ package chat
 
 import (
 	"net/http/httptest"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/vektah/gqlgen/client"
 	"github.com/vektah/gqlgen/handler"
+	"github.com/vektah/gqlgen/pubsub"
 )
 
 func TestChat(t *testing.T) {
-	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(New())))
+	r := New()
+	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(r)))
 	c := client.New(srv.URL)
 
 	t.Run("subscribe to chat events", func(t *testing.T) {
@@ -78,4 +82,50 @@ func TestChat(t *testing.T) {
 		require.Equal(t, "vektah", m.resp.MessageAdded.CreatedBy)
 	})
 
+	t.Run("subscribe to multiple rooms", func(t *testing.T) {
+		t.Parallel()
+
+		sub := c.Websocket(`subscription {
+			gophers: messageAdded(roomName:"#gophers-multi") { text }
+			rustaceans: messageAdded(roomName:"#rustaceans") { text }
+		}`)
+		defer sub.Close()
+
+		// wait for both fields to start observing their room, messages posted before then would be missed
+		observing := func(roomName string) bool {
+			return r.broker.(*pubsub.Memory).Subscribers(roomName) > 0
+		}
+		for deadline := time.Now().Add(time.Second); !observing("#gophers-multi") || !observing("#rustaceans"); {
+			require.True(t, time.Now().Before(deadline), "timed out waiting for subscription")
+			time.Sleep(time.Millisecond)
+		}
+
+		var gophersResp, rustaceansResp interface{}
+		c.MustPost(`mutation { post(text:"Hello gophers!", roomName:"#gophers-multi", username:"vektah") { id } }`, &gophersResp)
+		c.MustPost(`mutation { post(text:"Hello rustaceans!", roomName:"#rustaceans", username:"vektah") { id } }`, &rustaceansResp)
+
+		type message struct {
+			Text string
+		}
+		type resp struct {
+			Gophers    *message
+			Rustaceans *message
+		}
+
+		// each event only holds the field that produced it, but the rooms may deliver in either order
+		var texts []string
+		for i := 0; i < 2; i++ {
+			var event resp
+			require.NoError(t, sub.Next(&event))
+			if event.Gophers != nil {
+				require.Nil(t, event.Rustaceans)
+				texts = append(texts, event.Gophers.Text)
+			} else {
+				require.NotNil(t, event.Rustaceans)
+				texts = append(texts, event.Rustaceans.Text)
+			}
+		}
+		sort.Strings(texts)
+		require.Equal(t, []string{"Hello gophers!", "Hello rustaceans!"}, texts)
+	})
 }
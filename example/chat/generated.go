@@ -69,6 +69,12 @@ func (e *executableSchema) Schema() *schema.Schema {
 	return parsedSchema
 }
 
+func (e *executableSchema) Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	switch typeName + "." + fieldName {
+	}
+	return 0, false
+}
+
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
@@ -81,7 +87,7 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -97,7 +103,7 @@ func (e *executableSchema) Mutation(ctx context.Context, op *query.Operation) *g
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -105,26 +111,32 @@ func (e *executableSchema) Subscription(ctx context.Context, op *query.Operation
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
 	next := ec._Subscription(ctx, op.Selections)
-	if ec.Errors != nil {
-		return graphql.OneShot(&graphql.Response{Data: []byte("null"), Errors: ec.Errors})
+	if next == nil {
+		return graphql.OneShot(&graphql.Response{Data: []byte("null"), Errors: ec.GetErrors()})
 	}
 
 	var buf bytes.Buffer
 	return func() *graphql.Response {
+		var errs []*graphql.Error
 		buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
 			buf.Reset()
-			data := next()
+			event := next()
 
-			if data == nil {
+			if event == nil {
 				return nil
 			}
-			data.MarshalGQL(&buf)
+			event.MarshalGQL(&buf)
+			errs = event.Errors()
 			return buf.Bytes()
 		})
 
+		if buf == nil {
+			return nil
+		}
+
 		return &graphql.Response{
 			Data:   buf,
-			Errors: ec.Errors,
+			Errors: errs,
 		}
 	}
 }
@@ -157,7 +169,7 @@ func (ec *executionContext) _Chatroom(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Chatroom_name(ctx context.Context, field graphql.CollectedField, obj *Chatroom) graphql.Marshaler {
@@ -217,7 +229,7 @@ func (ec *executionContext) _Message(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Message_id(ctx context.Context, field graphql.CollectedField, obj *Message) graphql.Marshaler {
@@ -288,7 +300,7 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Mutation_post(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -371,7 +383,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Query_room(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -460,25 +472,30 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 var subscriptionImplementors = []string{"Subscription"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Subscription(ctx context.Context, sel []query.Selection) func() graphql.Marshaler {
+func (ec *executionContext) _Subscription(ctx context.Context, sel []query.Selection) func() *graphql.Event {
 	fields := graphql.CollectFields(ec.Doc, sel, subscriptionImplementors, ec.Variables)
 	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
 		Object: "Subscription",
 	})
-	if len(fields) != 1 {
-		ec.Errorf(ctx, "must subscribe to exactly one stream")
-		return nil
-	}
 
-	switch fields[0].Name {
-	case "messageAdded":
-		return ec._Subscription_messageAdded(ctx, fields[0])
-	default:
-		panic("unknown field " + strconv.Quote(fields[0].Name))
+	streams := make([]func() *graphql.Event, len(fields))
+	for i, field := range fields {
+		switch field.Name {
+		case "messageAdded":
+			streams[i] = ec._Subscription_messageAdded(ctx, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+
+		if streams[i] == nil {
+			return nil
+		}
 	}
+
+	return graphql.MergeStreams(ctx, streams)
 }
 
-func (ec *executionContext) _Subscription_messageAdded(ctx context.Context, field graphql.CollectedField) func() graphql.Marshaler {
+func (ec *executionContext) _Subscription_messageAdded(ctx context.Context, field graphql.CollectedField) func() *graphql.Event {
 	args := map[string]interface{}{}
 	var arg0 string
 	if tmp, ok := field.Args["roomName"]; ok {
@@ -490,20 +507,40 @@ func (ec *executionContext) _Subscription_messageAdded(ctx context.Context, fiel
 		}
 	}
 	args["roomName"] = arg0
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{Field: field})
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Subscription",
+		Args:   args,
+		Field:  field,
+	})
 	results, err := ec.resolvers.Subscription_messageAdded(ctx, args["roomName"].(string))
 	if err != nil {
 		ec.Error(ctx, err)
 		return nil
 	}
-	return func() graphql.Marshaler {
-		res, ok := <-results
+	return func() *graphql.Event {
+		event, ok := <-results
 		if !ok {
 			return nil
 		}
-		var out graphql.OrderedMap
-		out.Add(field.Alias, func() graphql.Marshaler { return ec._Message(ctx, field.Selections, &res) }())
-		return &out
+
+		return graphql.NewEvent(ctx, func(ctx context.Context) graphql.Marshaler {
+			var out graphql.OrderedMap
+			out.Add(field.Alias, func() graphql.Marshaler {
+				resTmp, err := ec.ResolverMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+					return event, nil
+				})
+				if err != nil {
+					ec.Error(ctx, err)
+					return graphql.Null
+				}
+				if resTmp == nil {
+					return graphql.Null
+				}
+				res := resTmp.(Message)
+				return ec._Message(ctx, field.Selections, &res)
+			}())
+			return &out
+		})
 	}
 }
 
@@ -533,7 +570,7 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) graphql.Marshaler {
@@ -627,7 +664,7 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) graphql.Marshaler {
@@ -710,7 +747,7 @@ func (ec *executionContext) ___Field(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) graphql.Marshaler {
@@ -820,7 +857,7 @@ func (ec *executionContext) ___InputValue(ctx context.Context, sel []query.Selec
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) graphql.Marshaler {
@@ -901,7 +938,7 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) graphql.Marshaler {
@@ -1019,7 +1056,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) graphql.Marshaler {
@@ -7,30 +7,33 @@ import (
 	"math/rand"
 	"sync"
 	"time"
+
+	"github.com/vektah/gqlgen/pubsub"
 )
 
 type resolvers struct {
-	Rooms map[string]*Chatroom
-	mu    sync.Mutex
+	Rooms  map[string]*Chatroom
+	mu     sync.Mutex
+	broker pubsub.Broker
 }
 
 func New() *resolvers {
 	return &resolvers{
-		Rooms: map[string]*Chatroom{},
+		Rooms:  map[string]*Chatroom{},
+		broker: pubsub.NewMemory(1),
 	}
 }
 
 type Chatroom struct {
-	Name      string
-	Messages  []Message
-	Observers map[string]chan Message
+	Name     string
+	Messages []Message
 }
 
 func (r *resolvers) Mutation_post(ctx context.Context, text string, userName string, roomName string) (Message, error) {
 	r.mu.Lock()
 	room := r.Rooms[roomName]
 	if room == nil {
-		room = &Chatroom{Name: roomName, Observers: map[string]chan Message{}}
+		room = &Chatroom{Name: roomName}
 		r.Rooms[roomName] = room
 	}
 	r.mu.Unlock()
@@ -42,12 +45,13 @@ func (r *resolvers) Mutation_post(ctx context.Context, text string, userName str
 		CreatedBy: userName,
 	}
 
-	room.Messages = append(room.Messages, message)
 	r.mu.Lock()
-	for _, observer := range room.Observers {
-		observer <- message
-	}
+	room.Messages = append(room.Messages, message)
 	r.mu.Unlock()
+
+	if err := r.broker.Publish(ctx, roomName, message); err != nil {
+		return Message{}, err
+	}
 	return message, nil
 }
 
@@ -55,7 +59,7 @@ func (r *resolvers) Query_room(ctx context.Context, name string) (*Chatroom, err
 	r.mu.Lock()
 	room := r.Rooms[name]
 	if room == nil {
-		room = &Chatroom{Name: name, Observers: map[string]chan Message{}}
+		room = &Chatroom{Name: name}
 		r.Rooms[name] = room
 	}
 	r.mu.Unlock()
@@ -64,28 +68,10 @@ func (r *resolvers) Query_room(ctx context.Context, name string) (*Chatroom, err
 }
 
 func (r *resolvers) Subscription_messageAdded(ctx context.Context, roomName string) (<-chan Message, error) {
-	r.mu.Lock()
-	room := r.Rooms[roomName]
-	if room == nil {
-		room = &Chatroom{Name: roomName, Observers: map[string]chan Message{}}
-		r.Rooms[roomName] = room
-	}
-	r.mu.Unlock()
-
-	id := randString(8)
 	events := make(chan Message, 1)
-
-	go func() {
-		<-ctx.Done()
-		r.mu.Lock()
-		delete(room.Observers, id)
-		r.mu.Unlock()
-	}()
-
-	r.mu.Lock()
-	room.Observers[id] = events
-	r.mu.Unlock()
-
+	if err := pubsub.Bind(ctx, r.broker, roomName, events); err != nil {
+		return nil, err
+	}
 	return events, nil
 }
 
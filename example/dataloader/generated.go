@@ -81,6 +81,12 @@ func (e *executableSchema) Schema() *schema.Schema {
 	return parsedSchema
 }
 
+func (e *executableSchema) Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	switch typeName + "." + fieldName {
+	}
+	return 0, false
+}
+
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
@@ -93,7 +99,7 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -135,7 +141,7 @@ func (ec *executionContext) _Address(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Address_id(ctx context.Context, field graphql.CollectedField, obj *Address) graphql.Marshaler {
@@ -197,7 +203,7 @@ func (ec *executionContext) _Customer(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Customer_id(ctx context.Context, field graphql.CollectedField, obj *Customer) graphql.Marshaler {
@@ -314,7 +320,7 @@ func (ec *executionContext) _Item(ctx context.Context, sel []query.Selection, ob
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Item_name(ctx context.Context, field graphql.CollectedField, obj *Item) graphql.Marshaler {
@@ -354,7 +360,7 @@ func (ec *executionContext) _Order(ctx context.Context, sel []query.Selection, o
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Order_id(ctx context.Context, field graphql.CollectedField, obj *Order) graphql.Marshaler {
@@ -459,7 +465,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Query_customers(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -643,7 +649,7 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) graphql.Marshaler {
@@ -737,7 +743,7 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) graphql.Marshaler {
@@ -820,7 +826,7 @@ func (ec *executionContext) ___Field(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) graphql.Marshaler {
@@ -930,7 +936,7 @@ func (ec *executionContext) ___InputValue(ctx context.Context, sel []query.Selec
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) graphql.Marshaler {
@@ -1011,7 +1017,7 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) graphql.Marshaler {
@@ -1129,7 +1135,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) graphql.Marshaler {
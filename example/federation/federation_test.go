@@ -0,0 +1,90 @@
+package federation
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/client"
+	"github.com/vektah/gqlgen/handler"
+)
+
+func TestFederation(t *testing.T) {
+	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(NewResolver())))
+	c := client.New(srv.URL)
+
+	t.Run("service sdl", func(t *testing.T) {
+		var resp struct {
+			Service struct{ SDL string } `json:"_service"`
+		}
+		c.MustPost(`{ _service { sdl } }`, &resp)
+
+		require.Contains(t, resp.Service.SDL, `extend type User @key(fields: "id")`)
+		require.NotContains(t, resp.Service.SDL, "_entities")
+	})
+
+	t.Run("resolve entities by key", func(t *testing.T) {
+		var resp struct {
+			Entities []struct {
+				Typename string `json:"__typename"`
+				ID       string
+				Body     string
+				Reviews  []struct{ Body string }
+			} `json:"_entities"`
+		}
+		c.MustPost(`query($representations: [_Any!]!) {
+			_entities(representations: $representations) {
+				__typename
+				... on Review { id body }
+				... on User { reviews { body } }
+			}
+		}`, &resp, client.Var("representations", []map[string]interface{}{
+			{"__typename": "Review", "id": "2"},
+			{"__typename": "User", "id": "2"},
+		}))
+
+		require.Len(t, resp.Entities, 2)
+		require.Equal(t, "Review", resp.Entities[0].Typename)
+		require.Equal(t, "Too expensive.", resp.Entities[0].Body)
+		require.Equal(t, "User", resp.Entities[1].Typename)
+		require.Len(t, resp.Entities[1].Reviews, 2)
+		require.Equal(t, "Could be better.", resp.Entities[1].Reviews[0].Body)
+	})
+
+	t.Run("required fields are copied from the representation", func(t *testing.T) {
+		var resp struct {
+			Entities []struct {
+				ShippingEstimate *int
+			} `json:"_entities"`
+		}
+		c.MustPost(`query($representations: [_Any!]!) {
+			_entities(representations: $representations) {
+				... on Product { shippingEstimate }
+			}
+		}`, &resp, client.Var("representations", []map[string]interface{}{
+			{"__typename": "Product", "upc": "1", "price": 899, "weight": 100},
+			{"__typename": "Product", "upc": "2", "price": 1299, "weight": 1000},
+			{"__typename": "Product", "upc": "3"},
+		}))
+
+		require.Len(t, resp.Entities, 3)
+		require.Equal(t, 50, *resp.Entities[0].ShippingEstimate)
+		require.Equal(t, 0, *resp.Entities[1].ShippingEstimate)
+		require.Nil(t, resp.Entities[2].ShippingEstimate)
+	})
+
+	t.Run("unknown entities are errors", func(t *testing.T) {
+		var resp struct {
+			Entities []*struct{ ID string } `json:"_entities"`
+		}
+		err := c.Post(`query($representations: [_Any!]!) {
+			_entities(representations: $representations) { ... on Review { id } }
+		}`, &resp, client.Var("representations", []map[string]interface{}{
+			{"__typename": "Review", "id": "1"},
+			{"__typename": "Planet", "id": "1"},
+			{"__typename": "Review", "id": "404"},
+		}))
+
+		require.EqualError(t, err, `[{"message":"\"Planet\" is not an entity","path":["_entities",1]},{"message":"review 404 not found","path":["_entities",2]}]`)
+	})
+}
@@ -0,0 +1,22 @@
+package federation
+
+type Review struct {
+	ID         string
+	Body       string
+	AuthorID   string
+	ProductUpc string
+}
+
+// User is owned by the accounts service, this service only knows the fields it is sent by the gateway.
+type User struct {
+	ID       string
+	Username *string
+}
+
+// Product is owned by the products service, price and weight are only set when the gateway sends them to resolve
+// shippingEstimate.
+type Product struct {
+	Upc    string
+	Price  *int
+	Weight *int
+}
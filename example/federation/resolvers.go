@@ -0,0 +1,97 @@
+//go:generate gorunpkg github.com/vektah/gqlgen
+
+package federation
+
+import (
+	context "context"
+	"fmt"
+)
+
+type Resolver struct {
+	reviews []Review
+	users   map[string]User
+}
+
+func NewResolver() *Resolver {
+	username := func(s string) *string { return &s }
+	return &Resolver{
+		reviews: []Review{
+			{ID: "1", Body: "Love it!", AuthorID: "1", ProductUpc: "1"},
+			{ID: "2", Body: "Too expensive.", AuthorID: "1", ProductUpc: "2"},
+			{ID: "3", Body: "Could be better.", AuthorID: "2", ProductUpc: "3"},
+			{ID: "4", Body: "Prefer something else.", AuthorID: "2", ProductUpc: "1"},
+		},
+		users: map[string]User{
+			"1": {ID: "1", Username: username("@ada")},
+			"2": {ID: "2", Username: username("@complete")},
+		},
+	}
+}
+
+func (r *Resolver) Query_topReviews(ctx context.Context, first int) ([]Review, error) {
+	if first > len(r.reviews) {
+		first = len(r.reviews)
+	}
+	return r.reviews[:first], nil
+}
+
+func (r *Resolver) Review_author(ctx context.Context, obj *Review) (User, error) {
+	// the gateway trusts the username provided here, so it doesn't need to ask the accounts service for it
+	return r.users[obj.AuthorID], nil
+}
+
+func (r *Resolver) Review_product(ctx context.Context, obj *Review) (Product, error) {
+	return Product{Upc: obj.ProductUpc}, nil
+}
+
+func (r *Resolver) User_reviews(ctx context.Context, obj *User) ([]Review, error) {
+	var reviews []Review
+	for _, review := range r.reviews {
+		if review.AuthorID == obj.ID {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+func (r *Resolver) Product_shippingEstimate(ctx context.Context, obj *Product) (*int, error) {
+	if obj.Price == nil || obj.Weight == nil {
+		return nil, nil
+	}
+
+	// free for expensive items
+	estimate := 0
+	if *obj.Price < 1000 {
+		// more expensive the heavier it is
+		estimate = *obj.Weight / 2
+	}
+	return &estimate, nil
+}
+
+func (r *Resolver) Product_reviews(ctx context.Context, obj *Product) ([]Review, error) {
+	var reviews []Review
+	for _, review := range r.reviews {
+		if review.ProductUpc == obj.Upc {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+func (r *Resolver) Entity_findReviewById(ctx context.Context, id string) (*Review, error) {
+	for _, review := range r.reviews {
+		if review.ID == id {
+			return &review, nil
+		}
+	}
+	return nil, fmt.Errorf("review %s not found", id)
+}
+
+func (r *Resolver) Entity_findUserById(ctx context.Context, id string) (*User, error) {
+	// users are owned by the accounts service, so any id it sends is valid here
+	return &User{ID: id}, nil
+}
+
+func (r *Resolver) Entity_findProductByUpc(ctx context.Context, upc string) (*Product, error) {
+	return &Product{Upc: upc}, nil
+}
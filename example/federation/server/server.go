@@ -0,0 +1,16 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/vektah/gqlgen/example/federation"
+	"github.com/vektah/gqlgen/handler"
+)
+
+func main() {
+	http.Handle("/", handler.Playground("Reviews", "/query"))
+	http.Handle("/query", handler.GraphQL(federation.MakeExecutableSchema(federation.NewResolver())))
+
+	log.Fatal(http.ListenAndServe(":4002", nil))
+}
@@ -75,6 +75,12 @@ func (e *executableSchema) Schema() *schema.Schema {
 	return parsedSchema
 }
 
+func (e *executableSchema) Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	switch typeName + "." + fieldName {
+	}
+	return 0, false
+}
+
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
@@ -87,7 +93,7 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -127,7 +133,7 @@ func (ec *executionContext) _Address(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Address_id(ctx context.Context, field graphql.CollectedField, obj *model.Address) graphql.Marshaler {
@@ -185,7 +191,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Query_user(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -364,7 +370,7 @@ func (ec *executionContext) _User(ctx context.Context, sel []query.Selection, ob
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _User_id(ctx context.Context, field graphql.CollectedField, obj *model.User) graphql.Marshaler {
@@ -519,7 +525,7 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) graphql.Marshaler {
@@ -613,7 +619,7 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) graphql.Marshaler {
@@ -696,7 +702,7 @@ func (ec *executionContext) ___Field(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) graphql.Marshaler {
@@ -806,7 +812,7 @@ func (ec *executionContext) ___InputValue(ctx context.Context, sel []query.Selec
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) graphql.Marshaler {
@@ -887,7 +893,7 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) graphql.Marshaler {
@@ -1005,7 +1011,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) graphql.Marshaler {
@@ -51,6 +51,12 @@ func (e *executableSchema) Schema() *schema.Schema {
 	return parsedSchema
 }
 
+func (e *executableSchema) Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	switch typeName + "." + fieldName {
+	}
+	return 0, false
+}
+
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
@@ -63,7 +69,7 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -107,7 +113,7 @@ func (ec *executionContext) _Like(ctx context.Context, sel []query.Selection, ob
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Like_reaction(ctx context.Context, field graphql.CollectedField, obj *Like) graphql.Marshaler {
@@ -198,7 +204,7 @@ func (ec *executionContext) _Post(ctx context.Context, sel []query.Selection, ob
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Post_message(ctx context.Context, field graphql.CollectedField, obj *Post) graphql.Marshaler {
@@ -291,7 +297,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Query_events(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -398,7 +404,7 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) graphql.Marshaler {
@@ -492,7 +498,7 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) graphql.Marshaler {
@@ -575,7 +581,7 @@ func (ec *executionContext) ___Field(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) graphql.Marshaler {
@@ -685,7 +691,7 @@ func (ec *executionContext) ___InputValue(ctx context.Context, sel []query.Selec
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) graphql.Marshaler {
@@ -766,7 +772,7 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) graphql.Marshaler {
@@ -884,7 +890,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) graphql.Marshaler {
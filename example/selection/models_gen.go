@@ -7,12 +7,14 @@ import (
 )
 
 type Event interface{}
+
 type Like struct {
 	Reaction  string    `json:"reaction"`
 	Sent      time.Time `json:"sent"`
 	Selection []string  `json:"selection"`
 	Collected []string  `json:"collected"`
 }
+
 type Post struct {
 	Message   string    `json:"message"`
 	Sent      time.Time `json:"sent"`
@@ -0,0 +1,81 @@
+//go:generate gorunpkg github.com/vektah/gqlgen -client
+
+package client
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/client"
+	"github.com/vektah/gqlgen/example/starwars"
+	"github.com/vektah/gqlgen/handler"
+)
+
+func TestGeneratedClient(t *testing.T) {
+	srv := httptest.NewServer(handler.GraphQL(starwars.MakeExecutableSchema(starwars.NewResolver())))
+	defer srv.Close()
+	c := NewClient(client.New(srv.URL))
+	ctx := context.Background()
+
+	t.Run("optional variables use their default", func(t *testing.T) {
+		resp, err := c.Hero(ctx, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, "Droid", resp.Hero.Typename)
+		require.Equal(t, "R2-D2", resp.Hero.Name)
+		require.Equal(t, "Astromech", *resp.Hero.PrimaryFunction)
+		require.Equal(t, "Luke Skywalker", resp.Hero.Friends[0].Name)
+		require.Empty(t, resp.Hero.Starships)
+	})
+
+	t.Run("fields selected on a concrete type", func(t *testing.T) {
+		episode := EpisodeEmpire
+		resp, err := c.Hero(ctx, &episode)
+		require.NoError(t, err)
+
+		require.Equal(t, "Human", resp.Hero.Typename)
+		require.Equal(t, "1000", resp.Hero.ID)
+		require.InDelta(t, 5.643, resp.Hero.HeightInFeet, 0.001)
+		require.Equal(t, "X-Wing", resp.Hero.Starships[0].Name)
+		require.Nil(t, resp.Hero.PrimaryFunction)
+	})
+
+	t.Run("unions", func(t *testing.T) {
+		resp, err := c.Search(ctx, "-")
+		require.NoError(t, err)
+
+		names := map[string]string{}
+		for _, result := range resp.Search {
+			names[result.Name] = result.Typename
+			switch result.Typename {
+			case "Starship":
+				require.NotZero(t, result.Length)
+			case "Droid":
+				require.NotEmpty(t, result.ID)
+			}
+		}
+		require.Equal(t, map[string]string{"R2-D2": "Droid", "C-3PO": "Droid", "X-Wing": "Starship"}, names)
+	})
+
+	t.Run("input objects and custom scalars", func(t *testing.T) {
+		commentary := "Typed"
+		since := time.Now().Add(-time.Minute)
+		created, err := c.CreateReview(ctx, EpisodeJedi, ReviewInput{Stars: 4, Commentary: &commentary})
+		require.NoError(t, err)
+		require.Equal(t, 4, created.CreateReview.Stars)
+		require.True(t, created.CreateReview.Time.After(since))
+
+		reviews, err := c.Reviews(ctx, EpisodeJedi, &since)
+		require.NoError(t, err)
+		require.Len(t, reviews.Reviews, 1)
+		require.Equal(t, "Typed", *reviews.Reviews[0].Commentary)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.Reviews(ctx, Episode("PHANTOM"), nil)
+		require.Error(t, err)
+	})
+}
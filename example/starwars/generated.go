@@ -163,6 +163,12 @@ func (e *executableSchema) Schema() *schema.Schema {
 	return parsedSchema
 }
 
+func (e *executableSchema) Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	switch typeName + "." + fieldName {
+	}
+	return 0, false
+}
+
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
@@ -175,7 +181,7 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -191,7 +197,7 @@ func (e *executableSchema) Mutation(ctx context.Context, op *query.Operation) *g
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
@@ -235,7 +241,7 @@ func (ec *executionContext) _Droid(ctx context.Context, sel []query.Selection, o
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Droid_id(ctx context.Context, field graphql.CollectedField, obj *Droid) graphql.Marshaler {
@@ -417,7 +423,7 @@ func (ec *executionContext) _FriendsConnection(ctx context.Context, sel []query.
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _FriendsConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *FriendsConnection) graphql.Marshaler {
@@ -542,7 +548,7 @@ func (ec *executionContext) _FriendsEdge(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _FriendsEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *FriendsEdge) graphql.Marshaler {
@@ -601,7 +607,7 @@ func (ec *executionContext) _Human(ctx context.Context, sel []query.Selection, o
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Human_id(ctx context.Context, field graphql.CollectedField, obj *Human) graphql.Marshaler {
@@ -851,7 +857,7 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Mutation_createReview(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -923,7 +929,7 @@ func (ec *executionContext) _PageInfo(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) graphql.Marshaler {
@@ -999,7 +1005,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Query_hero(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -1403,7 +1409,7 @@ func (ec *executionContext) _Review(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Review_stars(ctx context.Context, field graphql.CollectedField, obj *Review) graphql.Marshaler {
@@ -1468,7 +1474,7 @@ func (ec *executionContext) _Starship(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Starship_id(ctx context.Context, field graphql.CollectedField, obj *Starship) graphql.Marshaler {
@@ -1598,7 +1604,7 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) graphql.Marshaler {
@@ -1692,7 +1698,7 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) graphql.Marshaler {
@@ -1775,7 +1781,7 @@ func (ec *executionContext) ___Field(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) graphql.Marshaler {
@@ -1885,7 +1891,7 @@ func (ec *executionContext) ___InputValue(ctx context.Context, sel []query.Selec
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) graphql.Marshaler {
@@ -1966,7 +1972,7 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) graphql.Marshaler {
@@ -2084,7 +2090,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) graphql.Marshaler {
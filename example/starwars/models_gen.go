@@ -8,21 +8,34 @@ import (
 	strconv "strconv"
 )
 
+// A character from the Star Wars universe
 type Character interface{}
+
+// An edge object for a character's friends
 type FriendsEdge struct {
-	Cursor string    `json:"cursor"`
-	Node   Character `json:"node"`
+	// A cursor used for pagination
+	Cursor string `json:"cursor"`
+	// The character represented by this friendship edge
+	Node Character `json:"node"`
 }
+
+// Information for paginating this connection
 type PageInfo struct {
 	StartCursor string `json:"startCursor"`
 	EndCursor   string `json:"endCursor"`
 	HasNextPage bool   `json:"hasNextPage"`
 }
+
 type SearchResult interface{}
+
 type Starship struct {
-	ID      string  `json:"id"`
-	Name    string  `json:"name"`
-	Length  float64 `json:"length"`
+	// The ID of the starship
+	ID string `json:"id"`
+	// The name of the starship
+	Name string `json:"name"`
+	// Length of the starship, along the longest axis
+	Length float64 `json:"length"`
+	// coordinates tracking this ship
 	History [][]int `json:"history"`
 }
 
@@ -30,9 +43,11 @@ type Episode string
 
 const (
 	// Star Wars Episode IV: A New Hope, released in 1977.
-	EpisodeNewhope Episode = "NEWHOPE" // Star Wars Episode V: The Empire Strikes Back, released in 1980.
-	EpisodeEmpire  Episode = "EMPIRE"  // Star Wars Episode VI: Return of the Jedi, released in 1983.
-	EpisodeJedi    Episode = "JEDI"
+	EpisodeNewhope Episode = "NEWHOPE"
+	// Star Wars Episode V: The Empire Strikes Back, released in 1980.
+	EpisodeEmpire Episode = "EMPIRE"
+	// Star Wars Episode VI: Return of the Jedi, released in 1983.
+	EpisodeJedi Episode = "JEDI"
 )
 
 func (e Episode) IsValid() bool {
@@ -68,8 +83,9 @@ type LengthUnit string
 
 const (
 	// The standard unit around the world
-	LengthUnitMeter LengthUnit = "METER" // Primarily used in the United States
-	LengthUnitFoot  LengthUnit = "FOOT"
+	LengthUnitMeter LengthUnit = "METER"
+	// Primarily used in the United States
+	LengthUnitFoot LengthUnit = "FOOT"
 )
 
 func (e LengthUnit) IsValid() bool {
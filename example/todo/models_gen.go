@@ -7,6 +7,7 @@ type Todo struct {
 	Text string `json:"text"`
 	Done bool   `json:"done"`
 }
+
 type TodoInput struct {
 	Text string `json:"text"`
 	Done *bool  `json:"done"`
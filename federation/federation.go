@@ -0,0 +1,9 @@
+package federation
+
+// Service is returned by the _service field, describing this service's part of the graph to the federation gateway.
+type Service struct {
+	SDL string
+}
+
+// Entity is any object type with a @key directive, returned by the _entities field.
+type Entity interface{}
@@ -12,6 +12,10 @@ type Resolver func(ctx context.Context) (res interface{}, err error)
 type ResolverMiddleware func(ctx context.Context, next Resolver) (res interface{}, err error)
 type RequestMiddleware func(ctx context.Context, next func(ctx context.Context) []byte) []byte
 
+// DirectiveFunc implements a schema directive. It is called around the resolver of every field the directive has been
+// applied to, with the arguments given to the directive in the schema.
+type DirectiveFunc func(ctx context.Context, next Resolver, args map[string]interface{}) (res interface{}, err error)
+
 type RequestContext struct {
 	RawQuery  string
 	Variables map[string]interface{}
@@ -22,9 +26,16 @@ type RequestContext struct {
 	Recover            RecoverFunc
 	ResolverMiddleware ResolverMiddleware
 	RequestMiddleware  RequestMiddleware
+	// IncrementalDelivery enables the @defer and @stream directives. It should only be set by transports that are able
+	// to send the patches returned by NextPatch after the initial response, otherwise the directives are ignored.
+	IncrementalDelivery bool
 
 	errorsMu sync.Mutex
 	Errors   []*Error
+
+	patchesMu  sync.Mutex
+	patches    []*pendingPatch
+	sentErrors map[*Error]bool
 }
 
 func DefaultResolverMiddleware(ctx context.Context, next Resolver) (res interface{}, err error) {
@@ -52,6 +63,7 @@ type key string
 const (
 	request  key = "request_context"
 	resolver key = "resolver_context"
+	event    key = "event"
 )
 
 func GetRequestContext(ctx context.Context) *RequestContext {
@@ -120,18 +132,37 @@ func CollectFieldsCtx(ctx context.Context, satisfies []string) []CollectedField
 
 // Errorf sends an error string to the client, passing it through the formatter.
 func (c *RequestContext) Errorf(ctx context.Context, format string, args ...interface{}) {
+	c.addError(ctx, c.ErrorPresenter(ctx, fmt.Errorf(format, args...)))
+}
+
+// Error sends an error to the client, passing it through the formatter.
+func (c *RequestContext) Error(ctx context.Context, err error) {
+	c.addError(ctx, c.ErrorPresenter(ctx, err))
+}
+
+// addError adds the error to the subscription event being resolved, or to the request when there isn't one.
+func (c *RequestContext) addError(ctx context.Context, err *Error) {
+	if e := getEvent(ctx); e != nil {
+		e.addError(err)
+		return
+	}
+
 	c.errorsMu.Lock()
 	defer c.errorsMu.Unlock()
 
-	c.Errors = append(c.Errors, c.ErrorPresenter(ctx, fmt.Errorf(format, args...)))
+	c.Errors = append(c.Errors, err)
 }
 
-// Error sends an error to the client, passing it through the formatter.
-func (c *RequestContext) Error(ctx context.Context, err error) {
+// GetErrors returns a copy of the errors added so far. Deferred fragments and streamed items may still be adding
+// errors after the operation has returned, so Errors must only be read through here.
+func (c *RequestContext) GetErrors() []*Error {
 	c.errorsMu.Lock()
 	defer c.errorsMu.Unlock()
 
-	c.Errors = append(c.Errors, c.ErrorPresenter(ctx, err))
+	if c.Errors == nil {
+		return nil
+	}
+	return append([]*Error{}, c.Errors...)
 }
 
 // AddError is a convenience method for adding an error to the current response
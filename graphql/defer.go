@@ -28,3 +28,16 @@ func (d *deferred) MarshalGQL(w io.Writer) {
 	d.result.MarshalGQL(w)
 	d.mu.Unlock()
 }
+
+// resolved waits for a deferred result, returning the marshaler it resolved to.
+func resolved(m Marshaler) Marshaler {
+	d, ok := m.(*deferred)
+	if !ok {
+		return m
+	}
+
+	d.mu.Lock()
+	result := d.result
+	d.mu.Unlock()
+	return resolved(result)
+}
@@ -28,8 +28,11 @@ type ExtendedError interface {
 }
 
 func DefaultErrorPresenter(ctx context.Context, err error) *Error {
+	// the resolver context keeps pushing and popping its path as siblings are resolved, so take a copy
+	path := append([]interface{}{}, GetResolverContext(ctx).Path...)
+
 	if gqlerr, ok := err.(*Error); ok {
-		gqlerr.Path = GetResolverContext(ctx).Path
+		gqlerr.Path = path
 		return gqlerr
 	}
 
@@ -40,7 +43,7 @@ func DefaultErrorPresenter(ctx context.Context, err error) *Error {
 
 	return &Error{
 		Message:    err.Error(),
-		Path:       GetResolverContext(ctx).Path,
+		Path:       path,
 		Extensions: extensions,
 	}
 }
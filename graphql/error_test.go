@@ -0,0 +1,24 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultErrorPresenterCopiesPath(t *testing.T) {
+	ctx := WithResolverContext(context.Background(), &ResolverContext{})
+	rctx := GetResolverContext(ctx)
+
+	rctx.PushField("items")
+	rctx.PushIndex(0)
+	first := DefaultErrorPresenter(ctx, errors.New("first"))
+	rctx.Pop()
+	rctx.PushIndex(1)
+	second := DefaultErrorPresenter(ctx, errors.New("second"))
+
+	require.Equal(t, []interface{}{"items", 0}, first.Path)
+	require.Equal(t, []interface{}{"items", 1}, second.Path)
+}
@@ -27,6 +27,10 @@ func collectFields(doc *query.Document, selSet []query.Selection, satisfies []st
 	for _, sel := range selSet {
 		switch sel := sel.(type) {
 		case *query.Field:
+			if !shouldIncludeNode(sel.Directives, variables) {
+				continue
+			}
+
 			f := getOrCreateField(&groupedFields, sel.Alias.Name, func() CollectedField {
 				f := CollectedField{
 					Alias: sel.Alias.Name,
@@ -44,21 +48,24 @@ func collectFields(doc *query.Document, selSet []query.Selection, satisfies []st
 						}
 					}
 				}
+				f.Stream = streamDirective(sel.Directives, variables)
 				return f
 			})
 
 			f.Selections = append(f.Selections, sel.Selections...)
+			f.Defer = nil
 		case *query.InlineFragment:
-			if !instanceOf(sel.On.Ident.Name, satisfies) {
+			if !shouldIncludeNode(sel.Directives, variables) || !instanceOf(sel.On.Ident.Name, satisfies) {
 				continue
 			}
 
-			for _, childField := range collectFields(doc, sel.Selections, satisfies, variables, visited) {
-				f := getOrCreateField(&groupedFields, childField.Name, func() CollectedField { return childField })
-				f.Selections = append(f.Selections, childField.Selections...)
-			}
+			mergeFields(&groupedFields, collectFields(doc, sel.Selections, satisfies, variables, visited), deferDirective(sel.Directives, variables))
 
 		case *query.FragmentSpread:
+			if !shouldIncludeNode(sel.Directives, variables) {
+				continue
+			}
+
 			fragmentName := sel.Name.Name
 			if _, seen := visited[fragmentName]; seen {
 				continue
@@ -75,10 +82,7 @@ func collectFields(doc *query.Document, selSet []query.Selection, satisfies []st
 				continue
 			}
 
-			for _, childField := range collectFields(doc, fragment.Selections, satisfies, variables, visited) {
-				f := getOrCreateField(&groupedFields, childField.Name, func() CollectedField { return childField })
-				f.Selections = append(f.Selections, childField.Selections...)
-			}
+			mergeFields(&groupedFields, collectFields(doc, fragment.Selections, satisfies, variables, visited), deferDirective(sel.Directives, variables))
 
 		default:
 			panic(fmt.Errorf("unsupported %T", sel))
@@ -93,6 +97,44 @@ type CollectedField struct {
 	Name       string
 	Args       map[string]interface{}
 	Selections []query.Selection
+	// Defer is set when every selection of this field is inside a fragment marked with @defer
+	Defer *DeferDirective
+	// Stream is set when the field is marked with @stream
+	Stream *StreamDirective
+}
+
+// DeferDirective holds the arguments of a @defer directive applied to a fragment
+type DeferDirective struct {
+	Label string
+}
+
+// StreamDirective holds the arguments of a @stream directive applied to a list field
+type StreamDirective struct {
+	Label        string
+	InitialCount int
+}
+
+// mergeFields adds the fields collected from a fragment to the grouped fields. Fields selected outside of a deferred
+// fragment anywhere in the selection set are never deferred.
+func mergeFields(groupedFields *[]CollectedField, fields []CollectedField, deferred *DeferDirective) {
+	for _, childField := range fields {
+		childDefer := childField.Defer
+		if childDefer == nil {
+			childDefer = deferred
+		}
+
+		created := false
+		f := getOrCreateField(groupedFields, childField.Name, func() CollectedField {
+			created = true
+			return childField
+		})
+		f.Selections = append(f.Selections, childField.Selections...)
+		if created {
+			f.Defer = childDefer
+		} else if childDefer == nil {
+			f.Defer = nil
+		}
+	}
 }
 
 func instanceOf(val string, satisfies []string) bool {
@@ -104,6 +146,74 @@ func instanceOf(val string, satisfies []string) bool {
 	return false
 }
 
+// shouldIncludeNode evaluates the built in @skip and @include directives against the request variables.
+func shouldIncludeNode(directives common.DirectiveList, variables map[string]interface{}) bool {
+	skip, include := false, true
+
+	if d := directives.Get("skip"); d != nil {
+		skip = resolveIfArgument(d, variables)
+	}
+
+	if d := directives.Get("include"); d != nil {
+		include = resolveIfArgument(d, variables)
+	}
+
+	return !skip && include
+}
+
+func resolveIfArgument(d *common.Directive, variables map[string]interface{}) bool {
+	arg, ok := d.Args.Get("if")
+	if !ok {
+		// should never happen, validator has already run
+		panic(fmt.Errorf("%s: argument 'if' not defined", d.Name.Name))
+	}
+	value, _ := arg.Value(variables).(bool)
+	return value
+}
+
+// deferDirective reads the @defer directive of a fragment, returning nil if it is not present or disabled.
+func deferDirective(directives common.DirectiveList, variables map[string]interface{}) *DeferDirective {
+	d := directives.Get("defer")
+	if d == nil || !optionalIfArgument(d, variables) {
+		return nil
+	}
+
+	return &DeferDirective{Label: stringArgument(d, "label", variables)}
+}
+
+// streamDirective reads the @stream directive of a field, returning nil if it is not present or disabled.
+func streamDirective(directives common.DirectiveList, variables map[string]interface{}) *StreamDirective {
+	d := directives.Get("stream")
+	if d == nil || !optionalIfArgument(d, variables) {
+		return nil
+	}
+
+	stream := &StreamDirective{Label: stringArgument(d, "label", variables)}
+	if arg, ok := d.Args.Get("initialCount"); ok {
+		if count, err := UnmarshalInt(arg.Value(variables)); err == nil && count > 0 {
+			stream.InitialCount = count
+		}
+	}
+	return stream
+}
+
+// optionalIfArgument evaluates an if argument that defaults to true when it is not given.
+func optionalIfArgument(d *common.Directive, variables map[string]interface{}) bool {
+	if _, ok := d.Args.Get("if"); !ok {
+		return true
+	}
+	return resolveIfArgument(d, variables)
+}
+
+func stringArgument(d *common.Directive, name string, variables map[string]interface{}) string {
+	arg, ok := d.Args.Get(name)
+	if !ok {
+		return ""
+	}
+	value, _ := arg.Value(variables).(string)
+	return value
+}
+
 func getOrCreateField(c *[]CollectedField, name string, creator func() CollectedField) *CollectedField {
 	for i, cf := range *c {
 		if cf.Alias == name {
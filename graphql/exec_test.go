@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+func TestCollectFieldsDirectives(t *testing.T) {
+	collect := func(q string, variables map[string]interface{}) []string {
+		doc, err := query.Parse(q)
+		require.Nil(t, err)
+
+		var names []string
+		for _, f := range CollectFields(doc, doc.Operations[0].Selections, []string{"Query"}, variables) {
+			names = append(names, f.Alias)
+		}
+		return names
+	}
+
+	t.Run("skip literal", func(t *testing.T) {
+		require.Equal(t, []string{"b"}, collect(`{ a @skip(if: true) b @skip(if: false) }`, nil))
+	})
+
+	t.Run("include literal", func(t *testing.T) {
+		require.Equal(t, []string{"a"}, collect(`{ a @include(if: true) b @include(if: false) }`, nil))
+	})
+
+	t.Run("skip takes precedence over include", func(t *testing.T) {
+		require.Equal(t, []string{"b"}, collect(`{ a @skip(if: true) @include(if: true) b }`, nil))
+	})
+
+	t.Run("variables", func(t *testing.T) {
+		q := `query($skip: Boolean!, $include: Boolean!) { a @skip(if: $skip) b @include(if: $include) c }`
+		require.Equal(t, []string{"c"}, collect(q, map[string]interface{}{"skip": true, "include": false}))
+		require.Equal(t, []string{"a", "b", "c"}, collect(q, map[string]interface{}{"skip": false, "include": true}))
+	})
+
+	t.Run("inline fragments", func(t *testing.T) {
+		require.Equal(t, []string{"b"}, collect(`{ ... on Query @skip(if: true) { a } ... on Query @include(if: true) { b } }`, nil))
+	})
+
+	t.Run("fragment spreads", func(t *testing.T) {
+		q := `{ ...A @include(if: false) ...B @skip(if: false) } fragment A on Query { a } fragment B on Query { b }`
+		require.Equal(t, []string{"b"}, collect(q, nil))
+	})
+}
+
+func TestCollectFieldsIncremental(t *testing.T) {
+	collect := func(q string, variables map[string]interface{}) map[string]CollectedField {
+		doc, err := query.Parse(q)
+		require.Nil(t, err)
+
+		fields := map[string]CollectedField{}
+		for _, f := range CollectFields(doc, doc.Operations[0].Selections, []string{"Query"}, variables) {
+			fields[f.Alias] = f
+		}
+		return fields
+	}
+
+	t.Run("deferred inline fragment", func(t *testing.T) {
+		fields := collect(`{ a ... on Query @defer(label: "slow") { b } }`, nil)
+		require.Nil(t, fields["a"].Defer)
+		require.Equal(t, &DeferDirective{Label: "slow"}, fields["b"].Defer)
+	})
+
+	t.Run("deferred fragment spread", func(t *testing.T) {
+		fields := collect(`{ ...A @defer } fragment A on Query { a }`, nil)
+		require.Equal(t, &DeferDirective{}, fields["a"].Defer)
+	})
+
+	t.Run("disabled defer", func(t *testing.T) {
+		fields := collect(`query($defer: Boolean) { ... on Query @defer(if: $defer) { a } }`, map[string]interface{}{"defer": false})
+		require.Nil(t, fields["a"].Defer)
+	})
+
+	t.Run("fields selected outside of the deferred fragment are not deferred", func(t *testing.T) {
+		fields := collect(`{ ... on Query @defer { a b } a }`, nil)
+		require.Nil(t, fields["a"].Defer)
+		require.NotNil(t, fields["b"].Defer)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		fields := collect(`query($count: Int) { a @stream(label: "list", initialCount: $count) b }`, map[string]interface{}{"count": float64(2)})
+		require.Equal(t, &StreamDirective{Label: "list", InitialCount: 2}, fields["a"].Stream)
+		require.Nil(t, fields["b"].Stream)
+	})
+}
@@ -0,0 +1,211 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+type pendingPatch struct {
+	label string
+	path  []interface{}
+	// fields holds the aliases written by a deferred fragment, a streamed item owns everything below its path and
+	// leaves it nil.
+	fields []string
+	data   Marshaler
+}
+
+// contains reports whether the given response path is delivered as part of this patch
+func (p *pendingPatch) contains(path []interface{}) bool {
+	if len(path) <= len(p.path) {
+		return false
+	}
+	for i := range p.path {
+		if path[i] != p.path[i] {
+			return false
+		}
+	}
+	if p.fields == nil {
+		return true
+	}
+	for _, field := range p.fields {
+		if path[len(p.path)] == field {
+			return true
+		}
+	}
+	return false
+}
+
+// IncrementalFields is called by generated code once all the fields of an object have been resolved. When incremental
+// delivery is enabled, fields collected from a fragment marked with @defer are moved out of the object into patches,
+// and lists marked with @stream will only write their initial items, the remaining items becoming patches of their own.
+func (c *RequestContext) IncrementalFields(ctx context.Context, fields []CollectedField, out *OrderedMap) Marshaler {
+	if !c.IncrementalDelivery {
+		return out
+	}
+
+	path := []interface{}{}
+	if rctx := GetResolverContext(ctx); rctx != nil {
+		path = append(path, rctx.Path...)
+	}
+
+	initial := &OrderedMap{}
+	var patches []*pendingPatch
+	deferred := map[*DeferDirective]*pendingPatch{}
+	for i, field := range fields {
+		value := out.Values[i]
+		if field.Stream != nil {
+			value = &streamedList{
+				rc:     c,
+				stream: field.Stream,
+				path:   append(append([]interface{}{}, path...), out.Keys[i]),
+				value:  value,
+			}
+		}
+
+		if field.Defer == nil {
+			initial.Add(out.Keys[i], value)
+			continue
+		}
+
+		patch := deferred[field.Defer]
+		if patch == nil {
+			patch = &pendingPatch{label: field.Defer.Label, path: path, data: &OrderedMap{}}
+			deferred[field.Defer] = patch
+			patches = append(patches, patch)
+		}
+		patch.fields = append(patch.fields, out.Keys[i])
+		patch.data.(*OrderedMap).Add(out.Keys[i], value)
+	}
+
+	c.addPatches(patches...)
+	return initial
+}
+
+type streamedList struct {
+	rc     *RequestContext
+	stream *StreamDirective
+	path   []interface{}
+	value  Marshaler
+}
+
+func (s *streamedList) MarshalGQL(w io.Writer) {
+	arr, ok := resolved(s.value).(Array)
+	if !ok || len(arr) <= s.stream.InitialCount {
+		s.value.MarshalGQL(w)
+		return
+	}
+
+	var patches []*pendingPatch
+	for i := s.stream.InitialCount; i < len(arr); i++ {
+		patches = append(patches, &pendingPatch{
+			label: s.stream.Label,
+			path:  append(append([]interface{}{}, s.path...), i),
+			data:  arr[i],
+		})
+	}
+	s.rc.addPatches(patches...)
+
+	arr[:s.stream.InitialCount].MarshalGQL(w)
+}
+
+func (c *RequestContext) addPatches(patches ...*pendingPatch) {
+	if len(patches) == 0 {
+		return
+	}
+	c.patchesMu.Lock()
+	c.patches = append(c.patches, patches...)
+	c.patchesMu.Unlock()
+}
+
+// HasNext reports whether there are deferred fragments or streamed items still waiting to be sent with NextPatch.
+func (c *RequestContext) HasNext() bool {
+	c.patchesMu.Lock()
+	defer c.patchesMu.Unlock()
+
+	return len(c.patches) > 0
+}
+
+// InitialErrors returns the errors that belong in the initial response, leaving errors raised while resolving
+// deferred fragments and streamed items to be sent with the patch they are part of.
+func (c *RequestContext) InitialErrors() []*Error {
+	c.patchesMu.Lock()
+	defer c.patchesMu.Unlock()
+
+	return c.takeErrors(func(err *Error) bool {
+		return c.pendingPatchFor(err.Path) == nil
+	})
+}
+
+// NextPatch waits for the next deferred fragment or streamed item to finish resolving and returns it, or nil once
+// every patch has been sent. Patches nested inside another are only returned after the patch containing them.
+func (c *RequestContext) NextPatch() *Patch {
+	c.patchesMu.Lock()
+	var next *pendingPatch
+	for _, p := range c.patches {
+		if c.pendingPatchFor(p.path) == nil {
+			next = p
+			break
+		}
+	}
+	c.patchesMu.Unlock()
+
+	if next == nil {
+		return nil
+	}
+
+	// marshalling may add more patches, so it must happen before the patch is removed
+	var buf bytes.Buffer
+	next.data.MarshalGQL(&buf)
+
+	c.patchesMu.Lock()
+	defer c.patchesMu.Unlock()
+
+	for i, p := range c.patches {
+		if p == next {
+			c.patches = append(c.patches[:i], c.patches[i+1:]...)
+			break
+		}
+	}
+	hasNext := len(c.patches) > 0
+
+	return &Patch{
+		Data:  buf.Bytes(),
+		Path:  next.path,
+		Label: next.label,
+		Errors: c.takeErrors(func(err *Error) bool {
+			return !hasNext || next.contains(err.Path) && c.pendingPatchFor(err.Path) == nil
+		}),
+		HasNext: hasNext,
+	}
+}
+
+// pendingPatchFor finds a patch that has not been sent yet containing the given path. patchesMu must be held.
+func (c *RequestContext) pendingPatchFor(path []interface{}) *pendingPatch {
+	for _, p := range c.patches {
+		if p.contains(path) {
+			return p
+		}
+	}
+	return nil
+}
+
+// takeErrors returns the errors matching the filter that have not been sent yet, marking them as sent.
+func (c *RequestContext) takeErrors(filter func(err *Error) bool) []*Error {
+	c.errorsMu.Lock()
+	defer c.errorsMu.Unlock()
+
+	if c.sentErrors == nil {
+		c.sentErrors = map[*Error]bool{}
+	}
+
+	var errs []*Error
+	for _, err := range c.Errors {
+		if c.sentErrors[err] || !filter(err) {
+			continue
+		}
+		c.sentErrors[err] = true
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalDelivery(t *testing.T) {
+	marshal := func(m Marshaler) string {
+		var buf bytes.Buffer
+		m.MarshalGQL(&buf)
+		return buf.String()
+	}
+
+	patchJSON := func(p *Patch) string {
+		b, err := json.Marshal(p)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		rc := NewRequestContext(nil, "", nil)
+		out := &OrderedMap{}
+		out.Add("a", MarshalString("a"))
+
+		result := rc.IncrementalFields(context.Background(), []CollectedField{{Alias: "a", Defer: &DeferDirective{}}}, out)
+		require.Equal(t, `{"a":"a"}`, marshal(result))
+		require.False(t, rc.HasNext())
+	})
+
+	t.Run("deferred fields are sent after the initial response", func(t *testing.T) {
+		rc := NewRequestContext(nil, "", nil)
+		rc.IncrementalDelivery = true
+		ctx := WithResolverContext(context.Background(), &ResolverContext{})
+
+		slow := &DeferDirective{Label: "slow"}
+		out := &OrderedMap{}
+		out.Add("a", MarshalString("a"))
+		out.Add("b", Defer(func() Marshaler {
+			time.Sleep(10 * time.Millisecond)
+			return MarshalString("b")
+		}))
+		out.Add("c", MarshalString("c"))
+
+		result := rc.IncrementalFields(ctx, []CollectedField{{Alias: "a"}, {Alias: "b", Defer: slow}, {Alias: "c", Defer: slow}}, out)
+		require.Equal(t, `{"a":"a"}`, marshal(result))
+		require.True(t, rc.HasNext())
+
+		require.Equal(t, `{"data":{"b":"b","c":"c"},"path":[],"label":"slow","hasNext":false}`, patchJSON(rc.NextPatch()))
+		require.Nil(t, rc.NextPatch())
+	})
+
+	t.Run("streamed lists", func(t *testing.T) {
+		rc := NewRequestContext(nil, "", nil)
+		rc.IncrementalDelivery = true
+		ctx := WithResolverContext(context.Background(), &ResolverContext{})
+
+		out := &OrderedMap{}
+		out.Add("list", Array{MarshalInt(1), MarshalInt(2), MarshalInt(3)})
+
+		result := rc.IncrementalFields(ctx, []CollectedField{{Alias: "list", Stream: &StreamDirective{InitialCount: 1}}}, out)
+		require.Equal(t, `{"list":[1]}`, marshal(result))
+
+		require.Equal(t, `{"data":2,"path":["list",1],"hasNext":true}`, patchJSON(rc.NextPatch()))
+		require.Equal(t, `{"data":3,"path":["list",2],"hasNext":false}`, patchJSON(rc.NextPatch()))
+		require.Nil(t, rc.NextPatch())
+	})
+
+	t.Run("nested patches wait for their parent", func(t *testing.T) {
+		rc := NewRequestContext(nil, "", nil)
+		rc.IncrementalDelivery = true
+		ctx := WithResolverContext(context.Background(), &ResolverContext{})
+
+		// the nested fragment resolves first, but can only be sent once the object holding it has been
+		inner := &OrderedMap{}
+		inner.Add("b", MarshalString("b"))
+		innerCtx := WithResolverContext(ctx, &ResolverContext{Field: CollectedField{Alias: "a"}})
+		innerResult := rc.IncrementalFields(innerCtx, []CollectedField{{Alias: "b", Defer: &DeferDirective{Label: "inner"}}}, inner)
+
+		out := &OrderedMap{}
+		out.Add("a", Defer(func() Marshaler {
+			time.Sleep(10 * time.Millisecond)
+			return innerResult
+		}))
+		result := rc.IncrementalFields(ctx, []CollectedField{{Alias: "a", Defer: &DeferDirective{Label: "outer"}}}, out)
+		require.Equal(t, `{}`, marshal(result))
+
+		require.Equal(t, `{"data":{"a":{}},"path":[],"label":"outer","hasNext":true}`, patchJSON(rc.NextPatch()))
+		require.Equal(t, `{"data":{"b":"b"},"path":["a"],"label":"inner","hasNext":false}`, patchJSON(rc.NextPatch()))
+	})
+
+	t.Run("errors are sent with the patch they belong to", func(t *testing.T) {
+		rc := NewRequestContext(nil, "", nil)
+		rc.IncrementalDelivery = true
+		ctx := WithResolverContext(context.Background(), &ResolverContext{})
+
+		out := &OrderedMap{}
+		out.Add("a", Null)
+		out.Add("b", Null)
+		result := rc.IncrementalFields(ctx, []CollectedField{{Alias: "a"}, {Alias: "b", Defer: &DeferDirective{}}}, out)
+		require.Equal(t, `{"a":null}`, marshal(result))
+
+		rc.Error(WithResolverContext(ctx, &ResolverContext{Field: CollectedField{Alias: "a"}}), errors.New("a failed"))
+		rc.Error(WithResolverContext(ctx, &ResolverContext{Field: CollectedField{Alias: "b"}}), errors.New("b failed"))
+
+		require.Equal(t, []*Error{{Message: "a failed", Path: []interface{}{"a"}}}, rc.InitialErrors())
+		require.Equal(t, `{"data":{"b":null},"path":[],"errors":[{"message":"b failed","path":["b"]}],"hasNext":false}`, patchJSON(rc.NextPatch()))
+	})
+}
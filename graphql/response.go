@@ -9,6 +9,18 @@ import (
 type Response struct {
 	Data   json.RawMessage `json:"data"`
 	Errors []*Error        `json:"errors,omitempty"`
+	// HasNext is set when parts of the response have been deferred, and will be sent as patches
+	HasNext *bool `json:"hasNext,omitempty"`
+}
+
+// Patch is sent after the initial response of an operation using @defer or @stream, holding either the fields of
+// a deferred fragment or a single item of a streamed list.
+type Patch struct {
+	Data    json.RawMessage `json:"data"`
+	Path    []interface{}   `json:"path"`
+	Label   string          `json:"label,omitempty"`
+	Errors  []*Error        `json:"errors,omitempty"`
+	HasNext bool            `json:"hasNext"`
 }
 
 func ErrorResponse(ctx context.Context, messagef string, args ...interface{}) *Response {
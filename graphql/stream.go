@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Event is a single result of a subscription stream. The streams of every root field are resolved at the same time,
+// so the errors raised while resolving an event are collected on it instead of on the request.
+type Event struct {
+	Data Marshaler
+
+	errorsMu sync.Mutex
+	errors   []*Error
+}
+
+// NewEvent resolves an event of a subscription stream, any errors added under the context given to resolve end up on
+// the event.
+func NewEvent(ctx context.Context, resolve func(ctx context.Context) Marshaler) *Event {
+	e := &Event{}
+	e.Data = resolve(context.WithValue(ctx, event, e))
+	return e
+}
+
+func getEvent(ctx context.Context) *Event {
+	val := ctx.Value(event)
+	if val == nil {
+		return nil
+	}
+
+	return val.(*Event)
+}
+
+func (e *Event) MarshalGQL(w io.Writer) {
+	e.Data.MarshalGQL(w)
+}
+
+// Errors returns the errors raised while resolving the event. Fields may still be resolving until the event has been
+// marshalled, so only call it after that.
+func (e *Event) Errors() []*Error {
+	e.errorsMu.Lock()
+	defer e.errorsMu.Unlock()
+
+	return e.errors
+}
+
+func (e *Event) addError(err *Error) {
+	e.errorsMu.Lock()
+	defer e.errorsMu.Unlock()
+
+	e.errors = append(e.errors, err)
+}
+
+// MergeStreams combines the streams of each root field selected by a subscription into one. Events are returned as
+// soon as any of the fields produces one, and the merged stream ends once every field's stream has ended.
+func MergeStreams(ctx context.Context, streams []func() *Event) func() *Event {
+	if len(streams) == 1 {
+		return streams[0]
+	}
+
+	events := make(chan *Event)
+	var wg sync.WaitGroup
+	for _, next := range streams {
+		wg.Add(1)
+		go func(next func() *Event) {
+			defer wg.Done()
+			for e := next(); e != nil; e = next() {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(next)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return func() *Event {
+		e, ok := <-events
+		if !ok {
+			return nil
+		}
+		return e
+	}
+}
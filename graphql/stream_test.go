@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeStreams(t *testing.T) {
+	stream := func(values ...string) func() *Event {
+		return func() *Event {
+			if len(values) == 0 {
+				return nil
+			}
+			next := values[0]
+			values = values[1:]
+			return &Event{Data: MarshalString(next)}
+		}
+	}
+
+	collect := func(next func() *Event) []string {
+		var events []string
+		for event := next(); event != nil; event = next() {
+			var buf bytes.Buffer
+			event.MarshalGQL(&buf)
+			events = append(events, buf.String())
+		}
+		return events
+	}
+
+	t.Run("single stream is returned unchanged", func(t *testing.T) {
+		require.Equal(t, []string{`"a"`, `"b"`}, collect(MergeStreams(context.Background(), []func() *Event{stream("a", "b")})))
+	})
+
+	t.Run("events from every stream are returned until they all end", func(t *testing.T) {
+		events := collect(MergeStreams(context.Background(), []func() *Event{stream("a", "b"), stream(), stream("c")}))
+		sort.Strings(events)
+		require.Equal(t, []string{`"a"`, `"b"`, `"c"`}, events)
+	})
+
+	t.Run("errors stay with the event that raised them", func(t *testing.T) {
+		ctx := WithRequestContext(context.Background(), NewRequestContext(nil, "", nil))
+		ctx = WithResolverContext(ctx, &ResolverContext{})
+
+		// every event finishes resolving in the background, while the other streams are resolving theirs
+		var wg sync.WaitGroup
+		stream := func(name string) func() *Event {
+			i := 0
+			return func() *Event {
+				if i == 10 {
+					return nil
+				}
+				i++
+				return NewEvent(ctx, func(ctx context.Context) Marshaler {
+					wg.Add(1)
+					return Defer(func() Marshaler {
+						defer wg.Done()
+						AddErrorf(ctx, "%s", name)
+						return MarshalString(name)
+					})
+				})
+			}
+		}
+
+		next := MergeStreams(ctx, []func() *Event{stream("a"), stream("b"), stream("c")})
+		count := 0
+		for event := next(); event != nil; event = next() {
+			var buf bytes.Buffer
+			event.MarshalGQL(&buf)
+			require.Len(t, event.Errors(), 1)
+			require.Equal(t, strings.Trim(buf.String(), `"`), event.Errors()[0].Message)
+			count++
+		}
+		wg.Wait()
+		require.Equal(t, 30, count)
+		require.Empty(t, GetRequestContext(ctx).GetErrors())
+	})
+}
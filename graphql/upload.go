@@ -0,0 +1,30 @@
+package graphql
+
+import (
+	"fmt"
+	"io"
+)
+
+// Upload is a file sent as part of a multipart request, see https://github.com/jaydenseric/graphql-multipart-request-spec
+type Upload struct {
+	File        io.Reader
+	Filename    string
+	Size        int64
+	ContentType string
+}
+
+// MarshalUpload writes null, uploads can only be used as input.
+func MarshalUpload(f Upload) Marshaler {
+	return Null
+}
+
+func UnmarshalUpload(v interface{}) (Upload, error) {
+	switch v := v.(type) {
+	case Upload:
+		return v, nil
+	case *Upload:
+		return *v, nil
+	default:
+		return Upload{}, fmt.Errorf("%T is not an Upload, uploads must be sent as multipart requests", v)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/errors"
+)
+
+// BatchConcurrency sets how many operations from a single batched request may be executed at the same time.
+// Defaults to 1, running each operation in order.
+func BatchConcurrency(n int) Option {
+	return func(cfg *Config) {
+		cfg.batchConcurrency = n
+	}
+}
+
+// BatchLimit sets the maximum number of operations a single batched request may contain, larger batches are rejected
+// before any of them are run. Defaults to 0, allowing batches of any size.
+func BatchLimit(n int) Option {
+	return func(cfg *Config) {
+		cfg.batchLimit = n
+	}
+}
+
+// decodeOperations decodes a request body that holds either a single params object or an array of them, as sent by
+// batching clients like apollo-link-batch-http.
+func decodeOperations(b []byte) (operations []params, batch bool, err error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '[' {
+		var reqParams params
+		if err := json.Unmarshal(b, &reqParams); err != nil {
+			return nil, false, err
+		}
+		return []params{reqParams}, false, nil
+	}
+
+	if err := json.Unmarshal(b, &operations); err != nil {
+		return nil, true, err
+	}
+	if len(operations) == 0 {
+		return nil, true, fmt.Errorf("batch must contain at least one operation")
+	}
+	return operations, true, nil
+}
+
+// executeBatch runs each operation with its own request context and returns a json array holding their responses
+// in the order they were given.
+func (c *Config) executeBatch(ctx context.Context, exec graphql.ExecutableSchema, operations []params) []byte {
+	responses := make([]json.RawMessage, len(operations))
+
+	concurrency := c.batchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range operations {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				// execute only recovers from panics while resolving, anything before that would take down the server
+				if err := recover(); err != nil {
+					recoverFunc := c.recover
+					if recoverFunc == nil {
+						recoverFunc = graphql.DefaultRecover
+					}
+					userErr := recoverFunc(ctx, err)
+					responses[i] = encodeErrors(errors.Errorf("%s", userErr.Error()))
+				}
+				<-sem
+				wg.Done()
+			}()
+			_, responses[i], _ = c.execute(ctx, exec, &operations[i], false)
+		}(i)
+	}
+	wg.Wait()
+
+	b, err := json.Marshal(responses)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
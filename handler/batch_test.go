@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerBatch(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{})
+
+	t.Run("success", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `[{"query":"{ me { name } }"},{"query":"{ me { name } }"}]`)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, `[{"data":{"name":"test"}},{"data":{"name":"test"}}]`, resp.Body.String())
+	})
+
+	t.Run("errors are returned per operation", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `[{"query":"{ me { title } }"},{"query":"{ me { name } }"},{"query": "!"}]`)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, `[`+
+			`{"data":null,"errors":[{"message":"Cannot query field \"title\" on type \"User\".","locations":[{"line":1,"column":8}]}]},`+
+			`{"data":{"name":"test"}},`+
+			`{"data":null,"errors":[{"message":"syntax error: unexpected \"!\", expecting Ident","locations":[{"line":1,"column":1}]}]}`+
+			`]`, resp.Body.String())
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `[]`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.Equal(t, `{"data":null,"errors":[{"message":"json body could not be decoded: batch must contain at least one operation"}]}`, resp.Body.String())
+	})
+
+	t.Run("decode failure", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `[{"query": 1}]`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.Contains(t, resp.Body.String(), `{"data":null,"errors":[{"message":"json body could not be decoded: json: cannot unmarshal number`)
+	})
+}
+
+func TestHandlerBatchConcurrency(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{}, BatchConcurrency(4))
+
+	body := `[`
+	expected := `[`
+	for i := 0; i < 10; i++ {
+		if i != 0 {
+			body += ","
+			expected += ","
+		}
+		if i%2 == 0 {
+			body += `{"query":"{ me { name } }"}`
+			expected += `{"data":{"name":"test"}}`
+		} else {
+			body += `{"query":"{ me { title } }"}`
+			expected += `{"data":null,"errors":[{"message":"Cannot query field \"title\" on type \"User\".","locations":[{"line":1,"column":8}]}]}`
+		}
+	}
+	body += `]`
+	expected += `]`
+
+	resp := doRequest(h, "POST", "/graphql", body)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, expected, resp.Body.String())
+}
+
+type panickingCache struct{}
+
+func (panickingCache) Add(ctx context.Context, hash string, query string) {}
+
+func (panickingCache) Get(ctx context.Context, hash string) (string, bool) {
+	panic("cache unavailable")
+}
+
+func TestHandlerBatchRecover(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{},
+		PersistedQueries(panickingCache{}),
+		RecoverFunc(func(ctx context.Context, err interface{}) error {
+			return fmt.Errorf("recovered: %v", err)
+		}),
+	)
+
+	resp := doRequest(h, "POST", "/graphql", `[`+
+		`{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"abc"}}},`+
+		`{"query":"{ me { name } }"}`+
+		`]`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, `[{"data":null,"errors":[{"message":"recovered: cache unavailable"}]},{"data":{"name":"test"}}]`, resp.Body.String())
+}
+
+func TestHandlerBatchLimit(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{}, BatchLimit(2))
+
+	resp := doRequest(h, "POST", "/graphql", `[{"query":"{ me { name } }"},{"query":"{ me { name } }"}]`)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = doRequest(h, "POST", "/graphql", `[{"query":"{ me { name } }"},{"query":"{ me { name } }"},{"query":"{ me { name } }"}]`)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.Equal(t, `{"data":null,"errors":[{"message":"batch of 3 operations exceeds the limit of 2"}]}`, resp.Body.String())
+}
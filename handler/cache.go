@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// queryCache is a fixed size LRU of parsed and validated query documents, keyed by the raw query text.
+type queryCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type queryCacheEntry struct {
+	query string
+	doc   *query.Document
+	errs  []*errors.QueryError
+}
+
+func newQueryCache(size int) *queryCache {
+	return &queryCache{
+		size:  size,
+		ll:    list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+func (c *queryCache) get(query string) (*queryCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[query]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*queryCacheEntry), true
+}
+
+func (c *queryCache) add(entry *queryCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[entry.query]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value = entry
+		return
+	}
+
+	c.items[entry.query] = c.ll.PushFront(entry)
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*queryCacheEntry).query)
+	}
+}
+
+func (c *queryCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+func TestQueryCache(t *testing.T) {
+	c := newQueryCache(2)
+
+	c.add(&queryCacheEntry{query: "a"})
+	c.add(&queryCacheEntry{query: "b"})
+
+	_, ok := c.get("a")
+	require.True(t, ok)
+
+	c.add(&queryCacheEntry{query: "c"})
+	require.Equal(t, 2, c.len())
+
+	_, ok = c.get("b")
+	require.False(t, ok, "b should have been evicted as the least recently used entry")
+
+	_, ok = c.get("a")
+	require.True(t, ok)
+	_, ok = c.get("c")
+	require.True(t, ok)
+}
+
+// schemaCounter counts how often the handler needs the schema, which it only does when a query has to be parsed.
+type schemaCounter struct {
+	executableSchemaStub
+	calls int
+}
+
+func (e *schemaCounter) Schema() *schema.Schema {
+	e.calls++
+	return e.executableSchemaStub.Schema()
+}
+
+func TestHandlerCache(t *testing.T) {
+	exec := &schemaCounter{}
+	h := GraphQL(exec, CacheSize(10))
+
+	t.Run("caches successful queries", func(t *testing.T) {
+		exec.calls = 0
+		for i := 0; i < 2; i++ {
+			resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+		}
+		require.Equal(t, 1, exec.calls)
+	})
+
+	t.Run("caches validation failures", func(t *testing.T) {
+		exec.calls = 0
+		for i := 0; i < 2; i++ {
+			resp := doRequest(h, "POST", "/graphql", `{"query": "{ me { title }}"}`)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+			assert.Equal(t, `{"data":null,"errors":[{"message":"Cannot query field \"title\" on type \"User\".","locations":[{"line":1,"column":8}]}]}`, resp.Body.String())
+		}
+		require.Equal(t, 1, exec.calls)
+	})
+
+	t.Run("caches parse failures", func(t *testing.T) {
+		exec.calls = 0
+		for i := 0; i < 2; i++ {
+			resp := doRequest(h, "POST", "/graphql", `{"query": "!"}`)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+			assert.Equal(t, `{"data":null,"errors":[{"message":"syntax error: unexpected \"!\", expecting Ident","locations":[{"line":1,"column":1}]}]}`, resp.Body.String())
+		}
+		require.Equal(t, 1, exec.calls)
+	})
+
+	t.Run("every request is parsed without a cache", func(t *testing.T) {
+		exec := &schemaCounter{}
+		h := GraphQL(exec)
+		for i := 0; i < 2; i++ {
+			resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+			assert.Equal(t, http.StatusOK, resp.Code)
+		}
+		require.Equal(t, 2, exec.calls)
+	})
+}
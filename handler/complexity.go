@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/vektah/gqlgen/complexity"
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// ComplexityLimit rejects operations with a complexity above limit before any resolvers are called. Every field
+// costs 1 plus the complexity of its selections, unless overridden in gqlgen.yml or with ComplexityFunc.
+func ComplexityLimit(limit int) Option {
+	return func(cfg *Config) {
+		cfg.complexityLimit = limit
+	}
+}
+
+// ComplexityFunc sets the cost of fields when calculating the complexity of an operation. It takes precedence over
+// the costs configured in gqlgen.yml, which are used whenever it returns false.
+func ComplexityFunc(f complexity.FieldFunc) Option {
+	return func(cfg *Config) {
+		cfg.complexityFunc = f
+	}
+}
+
+// DepthLimit rejects operations that nest fields deeper than limit before any resolvers are called. Fragments are
+// followed but do not count as a level of their own.
+func DepthLimit(limit int) Option {
+	return func(cfg *Config) {
+		cfg.depthLimit = limit
+	}
+}
+
+// checkLimits returns an error if the operation exceeds the configured depth or complexity limits
+func (c *Config) checkLimits(exec graphql.ExecutableSchema, doc *query.Document, op *query.Operation, variables map[string]interface{}) *errors.QueryError {
+	if c.depthLimit > 0 {
+		if depth := complexity.Depth(doc, op); depth > c.depthLimit {
+			return errors.Errorf("operation has depth %d, which exceeds the limit of %d", depth, c.depthLimit)
+		}
+	}
+
+	if c.complexityLimit <= 0 {
+		return nil
+	}
+
+	fieldFunc := c.complexityFunc
+	if estimator, ok := exec.(complexity.Estimator); ok {
+		custom := c.complexityFunc
+		fieldFunc = func(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+			if custom != nil {
+				if cost, ok := custom(typeName, fieldName, childComplexity, args); ok {
+					return cost, true
+				}
+			}
+			return estimator.Complexity(typeName, fieldName, childComplexity, args)
+		}
+	}
+
+	if cost := complexity.Calculate(exec.Schema(), doc, op, variables, fieldFunc); cost > c.complexityLimit {
+		return errors.Errorf("operation has complexity %d, which exceeds the limit of %d", cost, c.complexityLimit)
+	}
+
+	return nil
+}
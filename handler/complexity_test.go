@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplexityLimit(t *testing.T) {
+	t.Run("below the limit", func(t *testing.T) {
+		h := GraphQL(&executableSchemaStub{}, ComplexityLimit(2))
+
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("above the limit", func(t *testing.T) {
+		h := GraphQL(&executableSchemaStub{}, ComplexityLimit(1))
+
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"operation has complexity 2, which exceeds the limit of 1"}]}`, resp.Body.String())
+	})
+
+	t.Run("custom field complexity", func(t *testing.T) {
+		h := GraphQL(&executableSchemaStub{}, ComplexityLimit(5), ComplexityFunc(func(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+			if typeName == "User" && fieldName == "name" {
+				return 10, true
+			}
+			return 0, false
+		}))
+
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"operation has complexity 11, which exceeds the limit of 5"}]}`, resp.Body.String())
+	})
+}
+
+func TestDepthLimit(t *testing.T) {
+	t.Run("below the limit", func(t *testing.T) {
+		h := GraphQL(&executableSchemaStub{}, DepthLimit(2))
+
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("above the limit", func(t *testing.T) {
+		h := GraphQL(&executableSchemaStub{}, DepthLimit(1))
+
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { ...F } } fragment F on User { name }"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"operation has depth 2, which exceeds the limit of 1"}]}`, resp.Body.String())
+	})
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"sync"
+)
+
+// ConnectionManager tracks the websocket connections open on a handler, and the subscriptions running on them, so
+// they can be drained when the server shuts down. Hijacked websocket connections are not closed by
+// http.Server.Shutdown, so Shutdown should be called alongside it.
+type ConnectionManager struct {
+	mu           sync.Mutex
+	conns        map[*wsConnection]struct{}
+	shuttingDown bool
+	drained      chan struct{}
+}
+
+func NewConnectionManager() *ConnectionManager {
+	return &ConnectionManager{
+		conns:   map[*wsConnection]struct{}{},
+		drained: make(chan struct{}),
+	}
+}
+
+// WebsocketConnectionManager registers every websocket connection made to the handler with the manager.
+func WebsocketConnectionManager(m *ConnectionManager) Option {
+	return func(cfg *Config) {
+		cfg.connectionManager = m
+	}
+}
+
+// Connections returns the number of open websocket connections
+func (m *ConnectionManager) Connections() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.conns)
+}
+
+// Subscriptions returns the number of subscriptions running across all open connections, counting queries that are
+// still sending @defer and @stream patches too.
+func (m *ConnectionManager) Subscriptions() int {
+	count := 0
+	for _, conn := range m.connections() {
+		count += conn.operations()
+	}
+	return count
+}
+
+// Shutdown stops accepting new websocket connections, cancels every running subscription and closes each connection
+// once its subscriptions have sent complete. It waits for the connections to finish closing, returning the context's
+// error if the context is done first.
+func (m *ConnectionManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.shuttingDown {
+		m.shuttingDown = true
+		if len(m.conns) == 0 {
+			close(m.drained)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, conn := range m.connections() {
+		// a resolver that ignores cancellation keeps its connection open, it must not hold up the others
+		go conn.shutdown()
+	}
+
+	select {
+	case <-m.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// connections copies the open connections, so they can be used without holding the lock
+func (m *ConnectionManager) connections() []*wsConnection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var conns []*wsConnection
+	for conn := range m.conns {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+func (m *ConnectionManager) add(conn *wsConnection) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.shuttingDown {
+		return false
+	}
+	m.conns[conn] = struct{}{}
+	return true
+}
+
+func (m *ConnectionManager) remove(conn *wsConnection) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.conns, conn)
+	if m.shuttingDown && len(m.conns) == 0 {
+		close(m.drained)
+	}
+}
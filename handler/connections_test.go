@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionManager(t *testing.T) {
+	m := NewConnectionManager()
+	srv := httptest.NewServer(GraphQL(&executableSchemaStub{}, WebsocketConnectionManager(m)))
+	defer srv.Close()
+
+	c := wsConnect(srv.URL)
+	defer c.Close()
+
+	require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+	require.Equal(t, connectionAckMsg, readOp(c).Type)
+	require.NoError(t, c.WriteJSON(&operationMessage{
+		Type:    startMsg,
+		ID:      "test_1",
+		Payload: json.RawMessage(`{"query": "subscription { user { title } }"}`),
+	}))
+	require.Equal(t, dataMsg, readOp(c).Type)
+
+	require.Equal(t, 1, m.Connections())
+	require.Equal(t, 1, m.Subscriptions())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+	require.Equal(t, 0, m.Connections())
+	require.Equal(t, 0, m.Subscriptions())
+
+	for {
+		msg := readOp(c)
+		if msg.Type == completeMsg {
+			require.Equal(t, "test_1", msg.ID)
+			break
+		}
+		require.Equal(t, dataMsg, msg.Type)
+	}
+
+	_, _, err := c.ReadMessage()
+	require.Equal(t, websocket.CloseGoingAway, err.(*websocket.CloseError).Code)
+
+	t.Run("new connections are refused", func(t *testing.T) {
+		c := wsConnect(srv.URL)
+		defer c.Close()
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, websocket.CloseGoingAway, err.(*websocket.CloseError).Code)
+	})
+}
+
+func TestConnectionManagerDeferredQueries(t *testing.T) {
+	m := NewConnectionManager()
+	srv := httptest.NewServer(GraphQL(&deferSchemaStub{release: make(chan struct{})}, WebsocketConnectionManager(m)))
+	defer srv.Close()
+
+	c := wsTransportConnect(srv.URL)
+	defer c.Close()
+
+	require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+	require.Equal(t, connectionAckMsg, readOp(c).Type)
+	require.NoError(t, c.WriteJSON(&operationMessage{
+		Type:    subscribeMsg,
+		ID:      "test_1",
+		Payload: json.RawMessage(`{"query": "{ me { ... @defer { name } } }"}`),
+	}))
+	require.Equal(t, nextMsg, readOp(c).Type)
+	require.Equal(t, 1, m.Subscriptions())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+	require.Equal(t, 0, m.Subscriptions())
+
+	msg := readOp(c)
+	require.Equal(t, completeMsg, msg.Type)
+	require.Equal(t, "test_1", msg.ID)
+
+	_, _, err := c.ReadMessage()
+	require.Equal(t, websocket.CloseGoingAway, err.(*websocket.CloseError).Code)
+}
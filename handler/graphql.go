@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/vektah/gqlgen/complexity"
 	"github.com/vektah/gqlgen/graphql"
 	"github.com/vektah/gqlgen/neelance/errors"
 	"github.com/vektah/gqlgen/neelance/query"
@@ -18,14 +21,33 @@ type params struct {
 	Query         string                 `json:"query"`
 	OperationName string                 `json:"operationName"`
 	Variables     map[string]interface{} `json:"variables"`
+	Extensions    *extensions            `json:"extensions"`
+}
+
+type extensions struct {
+	PersistedQuery *persistedQuery `json:"persistedQuery"`
 }
 
 type Config struct {
-	upgrader       websocket.Upgrader
-	recover        graphql.RecoverFunc
-	errorPresenter graphql.ErrorPresenterFunc
-	resolverHook   graphql.ResolverMiddleware
-	requestHook    graphql.RequestMiddleware
+	upgrader             websocket.Upgrader
+	recover              graphql.RecoverFunc
+	errorPresenter       graphql.ErrorPresenterFunc
+	resolverHook         graphql.ResolverMiddleware
+	requestHook          graphql.RequestMiddleware
+	persistedQueryCache  PersistedQueryCache
+	persistedQueriesOnly bool
+	queryCache           *queryCache
+	complexityLimit      int
+	depthLimit           int
+	uploadMaxMemory      int64
+	uploadMaxSize        int64
+	complexityFunc       complexity.FieldFunc
+	batchConcurrency     int
+	batchLimit           int
+	keepAliveInterval    time.Duration
+	websocketInitFunc    WebsocketInitFunc
+	websocketInitTimeout time.Duration
+	connectionManager    *ConnectionManager
 }
 
 func (c *Config) newRequestContext(doc *query.Document, query string, variables map[string]interface{}) *graphql.RequestContext {
@@ -49,6 +71,32 @@ func (c *Config) newRequestContext(doc *query.Document, query string, variables
 	return reqCtx
 }
 
+// parseQuery parses and validates a query, reusing the result of an earlier request for the same query text
+// when the query cache is enabled. The schema is only fetched when the query has to be parsed.
+func (c *Config) parseQuery(exec graphql.ExecutableSchema, q string) (*query.Document, []*errors.QueryError) {
+	if c.queryCache != nil {
+		if entry, ok := c.queryCache.get(q); ok {
+			return entry.doc, entry.errs
+		}
+	}
+
+	s := exec.Schema()
+	doc, qErr := query.Parse(q)
+	var errs []*errors.QueryError
+	if qErr != nil {
+		doc = nil
+		errs = []*errors.QueryError{qErr}
+	} else {
+		errs = validation.Validate(s, doc)
+	}
+
+	if c.queryCache != nil {
+		c.queryCache.add(&queryCacheEntry{query: q, doc: doc, errs: errs})
+	}
+
+	return doc, errs
+}
+
 type Option func(cfg *Config)
 
 func WebsocketUpgrader(upgrader websocket.Upgrader) Option {
@@ -110,6 +158,37 @@ func RequestMiddleware(middleware graphql.RequestMiddleware) Option {
 	}
 }
 
+// PersistedQueries enables automatic persisted queries. Clients may send the sha256 hash of a query in
+// extensions.persistedQuery.sha256Hash instead of the full query text, which will be looked up in the cache.
+// When a client sends both the hash and the query text the query is added to the cache.
+func PersistedQueries(cache PersistedQueryCache) Option {
+	return func(cfg *Config) {
+		cfg.persistedQueryCache = cache
+	}
+}
+
+// PersistedQueriesOnly rejects any operation that is not already in the persisted query cache. New queries will
+// not be added to the cache, making it an allowlist of known operations.
+func PersistedQueriesOnly(cache PersistedQueryCache) Option {
+	return func(cfg *Config) {
+		cfg.persistedQueryCache = cache
+		cfg.persistedQueriesOnly = true
+	}
+}
+
+// CacheSize sets the maximum number of parsed and validated queries to keep in memory, so repeated queries skip
+// parsing and validation. The least recently used query is evicted once the cache is full. A size of 0 or less
+// disables the cache, which is the default.
+func CacheSize(size int) Option {
+	return func(cfg *Config) {
+		if size <= 0 {
+			cfg.queryCache = nil
+			return
+		}
+		cfg.queryCache = newQueryCache(size)
+	}
+}
+
 func GraphQL(exec graphql.ExecutableSchema, options ...Option) http.HandlerFunc {
 	cfg := Config{
 		upgrader: websocket.Upgrader{
@@ -134,9 +213,11 @@ func GraphQL(exec graphql.ExecutableSchema, options ...Option) http.HandlerFunc
 			return
 		}
 
-		var reqParams params
+		var operations []params
+		var batch bool
 		switch r.Method {
 		case http.MethodGet:
+			var reqParams params
 			reqParams.Query = r.URL.Query().Get("query")
 			reqParams.OperationName = r.URL.Query().Get("operationName")
 
@@ -146,66 +227,151 @@ func GraphQL(exec graphql.ExecutableSchema, options ...Option) http.HandlerFunc
 					return
 				}
 			}
+
+			if extensions := r.URL.Query().Get("extensions"); extensions != "" {
+				if err := json.Unmarshal([]byte(extensions), &reqParams.Extensions); err != nil {
+					sendErrorf(w, http.StatusBadRequest, "extensions could not be decoded")
+					return
+				}
+			}
+			operations = []params{reqParams}
 		case http.MethodPost:
-			if err := json.NewDecoder(r.Body).Decode(&reqParams); err != nil {
-				sendErrorf(w, http.StatusBadRequest, "json body could not be decoded: "+err.Error())
-				return
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+				var cleanup func()
+				var err error
+				operations, batch, cleanup, err = cfg.parseMultipart(w, r)
+				if err != nil {
+					sendError(w, http.StatusBadRequest, &errors.QueryError{Message: err.Error()})
+					return
+				}
+				defer cleanup()
+			} else {
+				var body json.RawMessage
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					sendErrorf(w, http.StatusBadRequest, "json body could not be decoded: %s", err)
+					return
+				}
+
+				var err error
+				if operations, batch, err = decodeOperations(body); err != nil {
+					sendErrorf(w, http.StatusBadRequest, "json body could not be decoded: %s", err)
+					return
+				}
 			}
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
+		if batch && cfg.batchLimit > 0 && len(operations) > cfg.batchLimit {
+			sendErrorf(w, http.StatusBadRequest, "batch of %d operations exceeds the limit of %d", len(operations), cfg.batchLimit)
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 
-		doc, qErr := query.Parse(reqParams.Query)
-		if qErr != nil {
-			sendError(w, http.StatusUnprocessableEntity, qErr)
+		if batch {
+			w.Write(cfg.executeBatch(r.Context(), exec, operations))
 			return
 		}
 
-		errs := validation.Validate(exec.Schema(), doc)
-		if len(errs) != 0 {
-			sendError(w, http.StatusUnprocessableEntity, errs...)
+		if accepts(r, "text/event-stream") {
+			cfg.executeEventStream(r.Context(), w, exec, &operations[0])
 			return
 		}
 
-		op, err := doc.GetOperation(reqParams.OperationName)
-		if err != nil {
-			sendErrorf(w, http.StatusUnprocessableEntity, err.Error())
+		incremental := accepts(r, "multipart/mixed")
+		status, response, reqCtx := cfg.execute(r.Context(), exec, &operations[0], incremental)
+		if reqCtx != nil && reqCtx.HasNext() {
+			writeIncremental(r.Context(), w, reqCtx, response)
 			return
 		}
 
-		reqCtx := cfg.newRequestContext(doc, reqParams.Query, reqParams.Variables)
-		ctx := graphql.WithRequestContext(r.Context(), reqCtx)
+		w.WriteHeader(status)
+		w.Write(response)
+	})
+}
 
-		defer func() {
-			if err := recover(); err != nil {
-				userErr := reqCtx.Recover(ctx, err)
-				sendErrorf(w, http.StatusUnprocessableEntity, userErr.Error())
-			}
-		}()
+// execute runs a single operation, returning the encoded response and the http status it should be sent with. When
+// incremental is set @defer and @stream are enabled, and the request context is returned so the caller can send
+// any patches that follow the response.
+func (c *Config) execute(ctx context.Context, exec graphql.ExecutableSchema, reqParams *params, incremental bool) (status int, response []byte, reqCtx *graphql.RequestContext) {
+	doc, op, errs := c.prepare(ctx, exec, reqParams)
+	if persistedQueryMissed(errs) {
+		return http.StatusOK, encodeErrors(errs...), nil
+	}
+	if len(errs) != 0 {
+		return http.StatusUnprocessableEntity, encodeErrors(errs...), nil
+	}
 
-		switch op.Type {
-		case query.Query:
-			b, err := json.Marshal(exec.Query(ctx, op))
-			if err != nil {
-				panic(err)
-			}
-			w.Write(b)
-		case query.Mutation:
-			b, err := json.Marshal(exec.Mutation(ctx, op))
-			if err != nil {
-				panic(err)
-			}
-			w.Write(b)
-		default:
-			sendErrorf(w, http.StatusBadRequest, "unsupported operation type")
+	reqCtx = c.newRequestContext(doc, reqParams.Query, reqParams.Variables)
+	reqCtx.IncrementalDelivery = incremental
+	ctx = graphql.WithRequestContext(ctx, reqCtx)
+
+	defer func() {
+		if err := recover(); err != nil {
+			userErr := reqCtx.Recover(ctx, err)
+			status, response, reqCtx = http.StatusUnprocessableEntity, encodeErrors(errors.Errorf("%s", userErr.Error())), nil
 		}
-	})
+	}()
+
+	var result *graphql.Response
+	switch op.Type {
+	case query.Query:
+		result = exec.Query(ctx, op)
+	case query.Mutation:
+		result = exec.Mutation(ctx, op)
+	default:
+		return http.StatusBadRequest, encodeErrors(errors.Errorf("unsupported operation type")), nil
+	}
+
+	initialResponse(reqCtx, result)
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		panic(err)
+	}
+	return http.StatusOK, b, reqCtx
+}
+
+// prepare resolves persisted queries, then parses and validates the operation to be run, checking it against the
+// configured limits.
+func (c *Config) prepare(ctx context.Context, exec graphql.ExecutableSchema, reqParams *params) (*query.Document, *query.Operation, []*errors.QueryError) {
+	if qErr := c.resolvePersistedQuery(ctx, reqParams); qErr != nil {
+		return nil, nil, []*errors.QueryError{qErr}
+	}
+
+	doc, errs := c.parseQuery(exec, reqParams.Query)
+	if len(errs) != 0 {
+		return nil, nil, errs
+	}
+
+	op, err := doc.GetOperation(reqParams.OperationName)
+	if err != nil {
+		return nil, nil, []*errors.QueryError{errors.Errorf("%s", err.Error())}
+	}
+
+	if qErr := c.checkLimits(exec, doc, op, reqParams.Variables); qErr != nil {
+		return nil, nil, []*errors.QueryError{qErr}
+	}
+
+	return doc, op, nil
+}
+
+// accepts reports whether the client listed the media type in its Accept header
+func accepts(r *http.Request, mediaType string) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if t, _, err := mime.ParseMediaType(accept); err == nil && t == mediaType {
+			return true
+		}
+	}
+	return false
 }
 
 func sendError(w http.ResponseWriter, code int, errors ...*errors.QueryError) {
 	w.WriteHeader(code)
+	w.Write(encodeErrors(errors...))
+}
+
+func encodeErrors(errors ...*errors.QueryError) []byte {
 	var errs []*graphql.Error
 	for _, err := range errors {
 		var locations []graphql.ErrorLocation
@@ -227,7 +393,7 @@ func sendError(w http.ResponseWriter, code int, errors ...*errors.QueryError) {
 	if err != nil {
 		panic(err)
 	}
-	w.Write(b)
+	return b
 }
 
 func sendErrorf(w http.ResponseWriter, code int, format string, args ...interface{}) {
@@ -87,3 +87,17 @@ func doRequest(handler http.Handler, method string, target string, body string)
 	handler.ServeHTTP(w, r)
 	return w
 }
+
+func TestAccepts(t *testing.T) {
+	accepts := func(accept string, mediaType string) bool {
+		r := httptest.NewRequest("POST", "/graphql", nil)
+		r.Header.Set("Accept", accept)
+		return accepts(r, mediaType)
+	}
+
+	assert.True(t, accepts("multipart/mixed", "multipart/mixed"))
+	assert.True(t, accepts(`multipart/mixed; deferSpec=20220824, application/json`, "multipart/mixed"))
+	assert.True(t, accepts("application/json, text/event-stream", "text/event-stream"))
+	assert.False(t, accepts("application/json", "multipart/mixed"))
+	assert.False(t, accepts("", "text/event-stream"))
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/errors"
+)
+
+const (
+	multipartContentType = `multipart/mixed; boundary="-"`
+	multipartPart        = "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"
+	multipartEnd         = "\r\n-----\r\n"
+)
+
+// initialResponse moves errors that belong to pending patches out of the initial response, marking it as having more
+// payloads to follow.
+func initialResponse(reqCtx *graphql.RequestContext, result *graphql.Response) {
+	if !reqCtx.HasNext() {
+		return
+	}
+
+	hasNext := true
+	result.HasNext = &hasNext
+	result.Errors = reqCtx.InitialErrors()
+}
+
+// writeIncremental sends the initial response followed by each of its patches as parts of a multipart/mixed
+// response, flushing each part as soon as it is ready.
+func writeIncremental(ctx context.Context, w http.ResponseWriter, reqCtx *graphql.RequestContext, initial []byte) {
+	w.Header().Set("Content-Type", multipartContentType)
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	writePart := func(b []byte) {
+		io.WriteString(w, multipartPart)
+		w.Write(b)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	writePart(initial)
+	for {
+		patch, more := nextPatch(ctx, reqCtx)
+		if patch == nil {
+			break
+		}
+		writePart(patch)
+		if !more {
+			break
+		}
+	}
+	io.WriteString(w, multipartEnd)
+}
+
+// nextPatch encodes the next patch of an operation, or returns nil once they have all been sent. If a patch panics
+// the error is returned in its place and no more patches will follow.
+func nextPatch(ctx context.Context, reqCtx *graphql.RequestContext) (b []byte, more bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			userErr := reqCtx.Recover(ctx, err)
+			b, more = encodeErrors(errors.Errorf("%s", userErr.Error())), false
+		}
+	}()
+
+	patch := reqCtx.NextPatch()
+	if patch == nil {
+		return nil, false
+	}
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		panic(err)
+	}
+	return b, patch.HasNext
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/vektah/gqlgen/neelance/errors"
+)
+
+// PersistedQueryCache stores the text of persisted queries, keyed by the hex encoded sha256 hash of the query.
+type PersistedQueryCache interface {
+	Add(ctx context.Context, hash string, query string)
+	Get(ctx context.Context, hash string) (string, bool)
+}
+
+type persistedQuery struct {
+	Sha256  string `json:"sha256Hash"`
+	Version int64  `json:"version"`
+}
+
+const (
+	errPersistedQueryNotSupported = "PersistedQueryNotSupported"
+	errPersistedQueryNotFound     = "PersistedQueryNotFound"
+)
+
+// persistedQueryMissed is true when the only error is one that Apollo clients recover from by sending the full query
+// text. They only do so when the error comes in a 200 response, any other status is treated as a network error.
+func persistedQueryMissed(errs []*errors.QueryError) bool {
+	if len(errs) != 1 {
+		return false
+	}
+	return errs[0].Message == errPersistedQueryNotFound || errs[0].Message == errPersistedQueryNotSupported
+}
+
+// resolvePersistedQuery fills in the query text for requests that only send the hash of a persisted query,
+// and registers new queries in the cache.
+func (c *Config) resolvePersistedQuery(ctx context.Context, reqParams *params) *errors.QueryError {
+	var pq *persistedQuery
+	if reqParams.Extensions != nil {
+		pq = reqParams.Extensions.PersistedQuery
+	}
+
+	if pq == nil {
+		if c.persistedQueriesOnly {
+			return errors.Errorf("only persisted queries are allowed")
+		}
+		return nil
+	}
+
+	if c.persistedQueryCache == nil {
+		return errors.Errorf(errPersistedQueryNotSupported)
+	}
+
+	if pq.Version != 1 {
+		return errors.Errorf("unsupported persisted query version %d", pq.Version)
+	}
+
+	if reqParams.Query == "" {
+		query, ok := c.persistedQueryCache.Get(ctx, pq.Sha256)
+		if !ok {
+			return errors.Errorf(errPersistedQueryNotFound)
+		}
+		reqParams.Query = query
+		return nil
+	}
+
+	if c.persistedQueriesOnly {
+		if query, ok := c.persistedQueryCache.Get(ctx, pq.Sha256); !ok || query != reqParams.Query {
+			return errors.Errorf(errPersistedQueryNotFound)
+		}
+		return nil
+	}
+
+	hash := sha256.Sum256([]byte(reqParams.Query))
+	if hex.EncodeToString(hash[:]) != pq.Sha256 {
+		return errors.Errorf("provided sha256Hash does not match query")
+	}
+	c.persistedQueryCache.Add(ctx, pq.Sha256, reqParams.Query)
+
+	return nil
+}
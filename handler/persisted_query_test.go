@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapCache map[string]string
+
+func (m mapCache) Add(ctx context.Context, hash string, query string) {
+	m[hash] = query
+}
+
+func (m mapCache) Get(ctx context.Context, hash string) (string, bool) {
+	query, ok := m[hash]
+	return query, ok
+}
+
+const (
+	meQuery     = "{ me { name } }"
+	meQueryHash = "b8d9506e34c83b0e53c2aa463624fcea354713bc38f95276e6f0bd893ffb5b88"
+)
+
+func TestPersistedQueries(t *testing.T) {
+	cache := mapCache{}
+	h := GraphQL(&executableSchemaStub{}, PersistedQueries(cache))
+
+	t.Run("hash only misses", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"`+meQueryHash+`"}}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"PersistedQueryNotFound"}]}`, resp.Body.String())
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }","extensions":{"persistedQuery":{"version":1,"sha256Hash":"1234"}}}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"provided sha256Hash does not match query"}]}`, resp.Body.String())
+		assert.Len(t, cache, 0)
+	})
+
+	t.Run("query and hash registers", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }","extensions":{"persistedQuery":{"version":1,"sha256Hash":"`+meQueryHash+`"}}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+		assert.Equal(t, meQuery, cache[meQueryHash])
+	})
+
+	t.Run("hash only hits", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"`+meQueryHash+`"}}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("hash only hits over GET", func(t *testing.T) {
+		extensions := url.QueryEscape(`{"persistedQuery":{"version":1,"sha256Hash":"` + meQueryHash + `"}}`)
+		resp := doRequest(h, "GET", "/graphql?extensions="+extensions, "")
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("not supported without a cache", func(t *testing.T) {
+		resp := doRequest(GraphQL(&executableSchemaStub{}), "POST", "/graphql", `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"`+meQueryHash+`"}}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"PersistedQueryNotSupported"}]}`, resp.Body.String())
+	})
+}
+
+func TestPersistedQueriesOnly(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{}, PersistedQueriesOnly(mapCache{meQueryHash: meQuery}))
+
+	t.Run("known hash", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"`+meQueryHash+`"}}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":{"name":"test"}}`, resp.Body.String())
+	})
+
+	t.Run("unknown query is not registered", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name  } }","extensions":{"persistedQuery":{"version":1,"sha256Hash":"8ad31a5d3a6c5c6d0e1a3c0a7d0d6b5a3b7f4f1f0d2c3b4a5968778695a4b3c2"}}}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"PersistedQueryNotFound"}]}`, resp.Body.String())
+	})
+
+	t.Run("plain query", func(t *testing.T) {
+		resp := doRequest(h, "POST", "/graphql", `{"query":"{ me { name } }"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, `{"data":null,"errors":[{"message":"only persisted queries are allowed"}]}`, resp.Body.String())
+	})
+}
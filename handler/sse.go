@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// executeEventStream runs an operation for a client that asked for text/event-stream, sending each result as a
+// server-sent event until the operation completes or the client disconnects. Subscriptions send an event for every
+// result, queries and mutations send their response followed by any @defer and @stream patches.
+func (c *Config) executeEventStream(ctx context.Context, w http.ResponseWriter, exec graphql.ExecutableSchema, reqParams *params) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		sendErrorf(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	doc, op, errs := c.prepare(ctx, exec, reqParams)
+	if persistedQueryMissed(errs) {
+		sendError(w, http.StatusOK, errs...)
+		return
+	}
+	if len(errs) != 0 {
+		sendError(w, http.StatusUnprocessableEntity, errs...)
+		return
+	}
+
+	reqCtx := c.newRequestContext(doc, reqParams.Query, reqParams.Variables)
+	ctx, cancel := context.WithCancel(graphql.WithRequestContext(ctx, reqCtx))
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	defer func() {
+		if err := recover(); err != nil {
+			userErr := reqCtx.Recover(ctx, err)
+			writeEvent(w, "next", encodeErrors(errors.Errorf("%s", userErr.Error())))
+		}
+		writeEvent(w, "complete", nil)
+		flusher.Flush()
+	}()
+
+	switch op.Type {
+	case query.Subscription:
+		next := exec.Subscription(ctx, op)
+		for result := next(); result != nil; result = next() {
+			if ctx.Err() != nil {
+				return
+			}
+			writeEvent(w, "next", marshalEvent(result))
+			flusher.Flush()
+		}
+	default:
+		reqCtx.IncrementalDelivery = true
+
+		var result *graphql.Response
+		if op.Type == query.Query {
+			result = exec.Query(ctx, op)
+		} else {
+			result = exec.Mutation(ctx, op)
+		}
+
+		initialResponse(reqCtx, result)
+		writeEvent(w, "next", marshalEvent(result))
+		flusher.Flush()
+
+		for patch := reqCtx.NextPatch(); patch != nil; patch = reqCtx.NextPatch() {
+			writeEvent(w, "next", marshalEvent(patch))
+			flusher.Flush()
+		}
+	}
+}
+
+func marshalEvent(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// writeEvent writes a single server-sent event. Encoded json never contains a newline, so the data always fits on
+// one line.
+func writeEvent(w io.Writer, event string, data []byte) {
+	io.WriteString(w, "event: "+event+"\n")
+	if data != nil {
+		io.WriteString(w, "data: ")
+		w.Write(data)
+		io.WriteString(w, "\n")
+	}
+	io.WriteString(w, "\n")
+}
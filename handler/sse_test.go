@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStream(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{})
+
+	eventStreamRequest := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+		r.Header.Set("Accept", "text/event-stream")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("query", func(t *testing.T) {
+		resp := eventStreamRequest(`{"query":"{ me { name } }"}`)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "text/event-stream", resp.Header().Get("Content-Type"))
+		require.Equal(t, "event: next\ndata: {\"data\":{\"name\":\"test\"}}\n\nevent: complete\n\n", resp.Body.String())
+	})
+
+	t.Run("parse failure", func(t *testing.T) {
+		resp := eventStreamRequest(`{"query":"!"}`)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		require.Equal(t, `{"data":null,"errors":[{"message":"syntax error: unexpected \"!\", expecting Ident","locations":[{"line":1,"column":1}]}]}`, resp.Body.String())
+	})
+
+	t.Run("subscription", func(t *testing.T) {
+		srv := httptest.NewServer(h)
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		r, err := http.NewRequest("GET", srv.URL+"?query=subscription{user{title}}", nil)
+		require.NoError(t, err)
+		r.Header.Set("Accept", "text/event-stream")
+
+		resp, err := http.DefaultClient.Do(r.WithContext(ctx))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(resp.Body)
+		readLine := func() string {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			return line
+		}
+
+		for i := 0; i < 2; i++ {
+			require.Equal(t, "event: next\n", readLine())
+			require.Equal(t, "data: {\"data\":{\"name\":\"test\"}}\n", readLine())
+			require.Equal(t, "\n", readLine())
+		}
+	})
+}
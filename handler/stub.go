@@ -14,12 +14,14 @@ type executableSchemaStub struct {
 
 var _ graphql.ExecutableSchema = &executableSchemaStub{}
 
+var stubSchema = schema.MustParse(`
+	schema { query: Query }
+	type Query { me: User! }
+	type User { name: String! }
+`)
+
 func (e *executableSchemaStub) Schema() *schema.Schema {
-	return schema.MustParse(`
-		schema { query: Query }
-		type Query { me: User! }
-		type User { name: String! }
-	`)
+	return stubSchema
 }
 
 func (e *executableSchemaStub) Query(ctx context.Context, op *query.Operation) *graphql.Response {
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+const defaultUploadMaxMemory = 32 << 20
+
+// UploadMaxMemory sets the number of bytes of a multipart request that will be held in memory, the remaining
+// files are stored in temporary files on disk until the request is finished. Defaults to 32MB.
+func UploadMaxMemory(size int64) Option {
+	return func(cfg *Config) {
+		cfg.uploadMaxMemory = size
+	}
+}
+
+// UploadMaxSize limits the total size of a multipart request, including all files. Defaults to unlimited.
+func UploadMaxSize(size int64) Option {
+	return func(cfg *Config) {
+		cfg.uploadMaxSize = size
+	}
+}
+
+// parseMultipart decodes a request following https://github.com/jaydenseric/graphql-multipart-request-spec, setting
+// each file as a graphql.Upload in the variables given by the map field. The operations field may hold a batch, in
+// which case map paths are prefixed with the index of the operation. The returned cleanup func must be called once
+// the request is finished to release the files.
+func (c *Config) parseMultipart(w http.ResponseWriter, r *http.Request) (operations []params, batch bool, cleanup func(), err error) {
+	if c.uploadMaxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, c.uploadMaxSize)
+	}
+
+	maxMemory := c.uploadMaxMemory
+	if maxMemory <= 0 {
+		maxMemory = defaultUploadMaxMemory
+	}
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, false, nil, fmt.Errorf("failed to parse multipart form: %s", err.Error())
+	}
+
+	var files []io.Closer
+	cleanup = func() {
+		for _, f := range files {
+			f.Close()
+		}
+		r.MultipartForm.RemoveAll()
+	}
+
+	operations, batch, err = decodeOperations([]byte(r.Form.Get("operations")))
+	if err != nil {
+		cleanup()
+		return nil, false, nil, fmt.Errorf("operations form field could not be decoded")
+	}
+
+	var uploadsMap map[string][]string
+	if err := json.Unmarshal([]byte(r.Form.Get("map")), &uploadsMap); err != nil {
+		cleanup()
+		return nil, false, nil, fmt.Errorf("map form field could not be decoded")
+	}
+
+	for key, paths := range uploadsMap {
+		file, header, err := r.FormFile(key)
+		if err != nil {
+			cleanup()
+			return nil, false, nil, fmt.Errorf("failed to get key %s from form", key)
+		}
+		files = append(files, file)
+
+		upload := graphql.Upload{
+			File:        file,
+			Filename:    header.Filename,
+			Size:        header.Size,
+			ContentType: header.Header.Get("Content-Type"),
+		}
+
+		for _, path := range paths {
+			reqParams := &operations[0]
+			if batch {
+				if reqParams, path, err = batchedUploadPath(operations, path); err != nil {
+					cleanup()
+					return nil, false, nil, err
+				}
+			}
+
+			if err := setUpload(reqParams, path, upload); err != nil {
+				cleanup()
+				return nil, false, nil, err
+			}
+		}
+	}
+
+	return operations, batch, cleanup, nil
+}
+
+// batchedUploadPath splits a path like 0.variables.file into the operation it refers to and the path within it
+func batchedUploadPath(operations []params, path string) (*params, string, error) {
+	parts := strings.SplitN(path, ".", 2)
+	idx, err := strconv.Atoi(parts[0])
+	if err != nil || idx < 0 || idx >= len(operations) || len(parts) < 2 {
+		return nil, "", fmt.Errorf("invalid upload path %s, batched paths must start with an operation index", path)
+	}
+	return &operations[idx], parts[1], nil
+}
+
+// setUpload places the upload at an object path like variables.files.0 in the request params
+func setUpload(reqParams *params, path string, upload graphql.Upload) error {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 || parts[0] != "variables" {
+		return fmt.Errorf("invalid upload path %s, paths must start with variables", path)
+	}
+
+	if reqParams.Variables == nil {
+		reqParams.Variables = map[string]interface{}{}
+	}
+
+	var parent interface{} = reqParams.Variables
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if last {
+				p[part] = upload
+				return nil
+			}
+			parent = p[part]
+		case []interface{}:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(p) {
+				return fmt.Errorf("invalid upload path %s, %s is not a valid index", path, part)
+			}
+			if last {
+				p[idx] = upload
+				return nil
+			}
+			parent = p[idx]
+		default:
+			return fmt.Errorf("invalid upload path %s", path)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/graphql"
+)
+
+type testFile struct {
+	key      string
+	filename string
+	content  string
+}
+
+func multipartRequest(operations string, uploadsMap string, files ...testFile) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	mw.WriteField("operations", operations)
+	mw.WriteField("map", uploadsMap)
+	for _, f := range files {
+		w, err := mw.CreateFormFile(f.key, f.filename)
+		if err != nil {
+			panic(err)
+		}
+		w.Write([]byte(f.content))
+	}
+	mw.Close()
+
+	r := httptest.NewRequest("POST", "/graphql", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func readUpload(t *testing.T, v interface{}) (graphql.Upload, string) {
+	upload, err := graphql.UnmarshalUpload(v)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(upload.File)
+	require.NoError(t, err)
+	return upload, string(content)
+}
+
+func TestParseMultipart(t *testing.T) {
+	var cfg Config
+
+	t.Run("single file", func(t *testing.T) {
+		r := multipartRequest(
+			`{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`,
+			`{"0":["variables.file"]}`,
+			testFile{"0", "a.txt", "test"},
+		)
+
+		operations, _, cleanup, err := cfg.parseMultipart(httptest.NewRecorder(), r)
+		require.NoError(t, err)
+		defer cleanup()
+		reqParams := operations[0]
+
+		require.Equal(t, "mutation($file: Upload!) { upload(file: $file) }", reqParams.Query)
+		upload, content := readUpload(t, reqParams.Variables["file"])
+		require.Equal(t, "a.txt", upload.Filename)
+		require.Equal(t, int64(4), upload.Size)
+		require.Equal(t, "application/octet-stream", upload.ContentType)
+		require.Equal(t, "test", content)
+	})
+
+	t.Run("file lists", func(t *testing.T) {
+		r := multipartRequest(
+			`{"query":"mutation($files: [Upload!]!) { upload(files: $files) }","variables":{"files":[null,null]}}`,
+			`{"0":["variables.files.0"],"1":["variables.files.1"]}`,
+			testFile{"0", "a.txt", "first"},
+			testFile{"1", "b.txt", "second"},
+		)
+
+		operations, _, cleanup, err := cfg.parseMultipart(httptest.NewRecorder(), r)
+		require.NoError(t, err)
+		defer cleanup()
+		reqParams := operations[0]
+
+		files := reqParams.Variables["files"].([]interface{})
+		_, content := readUpload(t, files[0])
+		require.Equal(t, "first", content)
+		_, content = readUpload(t, files[1])
+		require.Equal(t, "second", content)
+	})
+
+	t.Run("invalid paths", func(t *testing.T) {
+		r := multipartRequest(
+			`{"query":"mutation($files: [Upload!]!) { upload(files: $files) }","variables":{"files":[]}}`,
+			`{"0":["variables.files.3"]}`,
+			testFile{"0", "a.txt", "test"},
+		)
+
+		_, _, _, err := cfg.parseMultipart(httptest.NewRecorder(), r)
+		require.EqualError(t, err, "invalid upload path variables.files.3, 3 is not a valid index")
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		r := multipartRequest(
+			`{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`,
+			`{"0":["variables.file"]}`,
+		)
+
+		_, _, _, err := cfg.parseMultipart(httptest.NewRecorder(), r)
+		require.EqualError(t, err, "failed to get key 0 from form")
+	})
+
+	t.Run("batched operations", func(t *testing.T) {
+		r := multipartRequest(
+			`[{"query":"{ me { name } }"},{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":null}}]`,
+			`{"0":["1.variables.file"]}`,
+			testFile{"0", "a.txt", "test"},
+		)
+
+		operations, batch, cleanup, err := cfg.parseMultipart(httptest.NewRecorder(), r)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.True(t, batch)
+		require.Len(t, operations, 2)
+		require.Nil(t, operations[0].Variables)
+		_, content := readUpload(t, operations[1].Variables["file"])
+		require.Equal(t, "test", content)
+	})
+
+	t.Run("batched paths without an index", func(t *testing.T) {
+		r := multipartRequest(
+			`[{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":null}}]`,
+			`{"0":["variables.file"]}`,
+			testFile{"0", "a.txt", "test"},
+		)
+
+		_, _, _, err := cfg.parseMultipart(httptest.NewRecorder(), r)
+		require.EqualError(t, err, "invalid upload path variables.file, batched paths must start with an operation index")
+	})
+}
+
+func TestHandlerMultipart(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{})
+
+	t.Run("success", func(t *testing.T) {
+		r := multipartRequest(`{"query":"{ me { name } }"}`, `{}`)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `{"data":{"name":"test"}}`, w.Body.String())
+	})
+
+	t.Run("decode failure", func(t *testing.T) {
+		r := multipartRequest(`notjson`, `{}`)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, `{"data":null,"errors":[{"message":"operations form field could not be decoded"}]}`, w.Body.String())
+	})
+}
@@ -7,12 +7,17 @@ import (
 	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/vektah/gqlgen/graphql"
 	"github.com/vektah/gqlgen/neelance/errors"
 	"github.com/vektah/gqlgen/neelance/query"
-	"github.com/vektah/gqlgen/neelance/validation"
+)
+
+const (
+	graphqlwsSubprotocol          = "graphql-ws"
+	graphqltransportwsSubprotocol = "graphql-transport-ws"
 )
 
 const (
@@ -25,63 +30,240 @@ const (
 	dataMsg                = "data"                 // Server -> Client
 	errorMsg               = "error"                // Server -> Client
 	completeMsg            = "complete"             // Server -> Client
-	//connectionKeepAliveMsg = "ka"                 // Server -> Client  TODO: keepalives
+	connectionKeepAliveMsg = "ka"                   // Server -> Client
+)
+
+// graphql-transport-ws replaces start, stop and data with the messages below, the client sends complete to stop an
+// operation and keepalives are done with ping and pong.
+const (
+	subscribeMsg = "subscribe" // Client -> Server
+	nextMsg      = "next"      // Server -> Client
+	pingMsg      = "ping"      // bidirectional
+	pongMsg      = "pong"      // bidirectional
+)
+
+// close codes defined by graphql-transport-ws
+const (
+	invalidMessageCode    = 4400
+	unauthorizedCode      = 4401
+	forbiddenCode         = 4403
+	initTimeoutCode       = 4408
+	subscriberExistsCode  = 4409
+	tooManyInitialiseCode = 4429
 )
 
+// WebsocketKeepAliveInterval sends a keepalive to websocket clients at the given interval so that idle connections are
+// not closed by proxies and load balancers. graphql-ws clients are sent ka messages and graphql-transport-ws clients
+// are sent pings. Defaults to 0, sending no keepalives.
+func WebsocketKeepAliveInterval(interval time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.keepAliveInterval = interval
+	}
+}
+
+// WebsocketInitTimeout closes websocket connections that have not sent connection_init within the timeout, and have
+// not been acknowledged by then. Defaults to 0, waiting forever.
+func WebsocketInitTimeout(timeout time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.websocketInitTimeout = timeout
+	}
+}
+
 type operationMessage struct {
 	Payload json.RawMessage `json:"payload,omitempty"`
 	ID      string          `json:"id,omitempty"`
 	Type    string          `json:"type"`
 }
 
+// InitPayload is the payload of the connection_init message sent by websocket clients, commonly used to pass
+// credentials as there is no way to set headers on a websocket from the browser.
+type InitPayload map[string]interface{}
+
+// GetString returns the string value of a key in the payload, or an empty string if it is missing or not a string.
+func (p InitPayload) GetString(key string) string {
+	value, _ := p[key].(string)
+	return value
+}
+
+// WebsocketInitFunc is called with the payload of connection_init before a websocket connection is acknowledged.
+// Returning an error rejects the connection, otherwise the returned context, which should be derived from ctx, is used
+// for every operation that runs on the connection.
+type WebsocketInitFunc func(ctx context.Context, initPayload InitPayload) (context.Context, error)
+
+// WebsocketInit sets a function to authenticate websocket connections from their init payload.
+func WebsocketInit(f WebsocketInitFunc) Option {
+	return func(cfg *Config) {
+		cfg.websocketInitFunc = f
+	}
+}
+
 type wsConnection struct {
-	ctx    context.Context
-	conn   *websocket.Conn
-	exec   graphql.ExecutableSchema
-	active map[string]context.CancelFunc
-	mu     sync.Mutex
-	cfg    *Config
+	ctx  context.Context
+	conn *websocket.Conn
+	exec graphql.ExecutableSchema
+	// mu guards active and closing, writeMu is held while writing to conn so that writes never block them
+	active   map[string]*wsOperation
+	closing  bool
+	mu       sync.Mutex
+	writeMu  sync.Mutex
+	running  sync.WaitGroup
+	cfg      *Config
+	protocol string
+}
+
+// wsOperation is an operation running on a connection. A subscription, or a query that is still sending patches,
+// runs until its goroutine ends it, it is only cancelled by the client or a shutdown.
+type wsOperation struct {
+	cancel context.CancelFunc
 }
 
 func connectWs(exec graphql.ExecutableSchema, w http.ResponseWriter, r *http.Request, cfg *Config) {
+	protocol := graphqlwsSubprotocol
+	for _, p := range websocket.Subprotocols(r) {
+		if p == graphqltransportwsSubprotocol {
+			protocol = p
+			break
+		}
+	}
+
 	ws, err := cfg.upgrader.Upgrade(w, r, http.Header{
-		"Sec-Websocket-Protocol": []string{"graphql-ws"},
+		"Sec-Websocket-Protocol": []string{protocol},
 	})
 	if err != nil {
 		log.Printf("unable to upgrade %T to websocket %s: ", w, err.Error())
 		sendErrorf(w, http.StatusBadRequest, "unable to upgrade")
 		return
 	}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	conn := &wsConnection{
+		active:   map[string]*wsOperation{},
+		exec:     exec,
+		conn:     ws,
+		ctx:      ctx,
+		cfg:      cfg,
+		protocol: protocol,
+	}
 
-	conn := wsConnection{
-		active: map[string]context.CancelFunc{},
-		exec:   exec,
-		conn:   ws,
-		ctx:    r.Context(),
-		cfg:    cfg,
+	if m := cfg.connectionManager; m != nil {
+		if !m.add(conn) {
+			conn.close(websocket.CloseGoingAway, "server shutting down")
+			return
+		}
+		defer m.remove(conn)
 	}
 
 	if !conn.init() {
 		return
 	}
 
+	if cfg.keepAliveInterval > 0 {
+		go conn.keepAlive(cfg.keepAliveInterval)
+	}
+
 	conn.run()
 }
 
+func (c *wsConnection) transportWS() bool {
+	return c.protocol == graphqltransportwsSubprotocol
+}
+
+// messageType maps graphql-transport-ws messages sent by the client onto their graphql-ws equivalents
+func (c *wsConnection) messageType(message *operationMessage) string {
+	if !c.transportWS() {
+		return message.Type
+	}
+
+	switch message.Type {
+	case subscribeMsg:
+		return startMsg
+	case completeMsg:
+		return stopMsg
+	case startMsg, stopMsg, connectionTerminateMsg:
+		// not part of graphql-transport-ws
+		return ""
+	default:
+		return message.Type
+	}
+}
+
+func (c *wsConnection) keepAlive(interval time.Duration) {
+	msgType := connectionKeepAliveMsg
+	if c.transportWS() {
+		msgType = pingMsg
+	} else {
+		c.write(&operationMessage{Type: connectionKeepAliveMsg})
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.write(&operationMessage{Type: msgType})
+		}
+	}
+}
+
 func (c *wsConnection) init() bool {
+	if timeout := c.cfg.websocketInitTimeout; timeout > 0 {
+		timer := time.AfterFunc(timeout, c.initTimeout)
+		defer timer.Stop()
+	}
+
 	message := c.readOp()
+	for message != nil && c.transportWS() && (message.Type == pingMsg || message.Type == pongMsg) {
+		// graphql-transport-ws allows pings at any time, even before the connection is acknowledged
+		if message.Type == pingMsg {
+			c.write(&operationMessage{Type: pongMsg, Payload: message.Payload})
+		}
+		message = c.readOp()
+	}
 	if message == nil {
 		c.close(websocket.CloseProtocolError, "decoding error")
 		return false
 	}
 
-	switch message.Type {
+	switch c.messageType(message) {
 	case connectionInitMsg:
+		if c.cfg.websocketInitFunc != nil {
+			var payload InitPayload
+			if len(message.Payload) > 0 {
+				if err := json.Unmarshal(message.Payload, &payload); err != nil {
+					c.rejectInit("invalid init payload")
+					return false
+				}
+			}
+
+			ctx, err := c.cfg.websocketInitFunc(c.ctx, payload)
+			if err != nil {
+				c.rejectInit(err.Error())
+				return false
+			}
+			c.ctx = ctx
+		}
+
 		c.write(&operationMessage{Type: connectionAckMsg})
 	case connectionTerminateMsg:
 		c.close(websocket.CloseNormalClosure, "terminated")
 		return false
+	case startMsg:
+		if c.transportWS() {
+			c.close(unauthorizedCode, "Unauthorized")
+			return false
+		}
+		fallthrough
 	default:
+		if c.transportWS() {
+			c.close(invalidMessageCode, fmt.Sprintf("unexpected message %s", message.Type))
+			return false
+		}
 		c.sendConnectionError("unexpected message %s", message.Type)
 		c.close(websocket.CloseProtocolError, "unexpected message")
 		return false
@@ -90,10 +272,35 @@ func (c *wsConnection) init() bool {
 	return true
 }
 
+// initTimeout closes a connection that has not been initialised in time. Closing it fails the pending read in init.
+func (c *wsConnection) initTimeout() {
+	if c.transportWS() {
+		c.close(initTimeoutCode, "Connection initialisation timeout")
+		return
+	}
+	c.sendConnectionError("connection initialisation timeout")
+	c.close(websocket.CloseNormalClosure, "init timeout")
+}
+
+// rejectInit refuses a connection_init, graphql-ws clients are sent a connection_error before the connection is closed
+// while graphql-transport-ws only has a close code for it.
+func (c *wsConnection) rejectInit(message string) {
+	if c.transportWS() {
+		c.close(forbiddenCode, message)
+		return
+	}
+	c.sendConnectionError("%s", message)
+	c.close(websocket.CloseNormalClosure, "init rejected")
+}
+
 func (c *wsConnection) write(msg *operationMessage) {
-	c.mu.Lock()
+	if c.transportWS() && msg.Type == dataMsg {
+		msg.Type = nextMsg
+	}
+
+	c.writeMu.Lock()
 	c.conn.WriteJSON(msg)
-	c.mu.Unlock()
+	c.writeMu.Unlock()
 }
 
 func (c *wsConnection) run() {
@@ -103,25 +310,41 @@ func (c *wsConnection) run() {
 			return
 		}
 
-		switch message.Type {
+		switch c.messageType(message) {
 		case startMsg:
 			if !c.subscribe(message) {
 				return
 			}
 		case stopMsg:
 			c.mu.Lock()
-			closer := c.active[message.ID]
+			operation := c.active[message.ID]
+			if c.transportWS() {
+				// the client has already completed the operation, so the server must not send complete for it
+				delete(c.active, message.ID)
+			}
 			c.mu.Unlock()
-			if closer == nil {
-				c.sendError(message.ID, errors.Errorf("%s is not running, cannot stop", message.ID))
+			if operation == nil {
+				if !c.transportWS() {
+					c.sendError(message.ID, errors.Errorf("%s is not running, cannot stop", message.ID))
+				}
 				continue
 			}
 
-			closer()
+			operation.cancel()
+		case pingMsg:
+			c.write(&operationMessage{Type: pongMsg, Payload: message.Payload})
+		case pongMsg:
 		case connectionTerminateMsg:
 			c.close(websocket.CloseNormalClosure, "terminated")
 			return
 		default:
+			if c.transportWS() && message.Type == connectionInitMsg {
+				c.close(tooManyInitialiseCode, "Too many initialisation requests")
+				return
+			} else if c.transportWS() {
+				c.close(invalidMessageCode, fmt.Sprintf("unexpected message %s", message.Type))
+				return
+			}
 			c.sendConnectionError("unexpected message %s", message.Type)
 			c.close(websocket.CloseProtocolError, "unexpected message")
 			return
@@ -132,32 +355,41 @@ func (c *wsConnection) run() {
 func (c *wsConnection) subscribe(message *operationMessage) bool {
 	var reqParams params
 	if err := json.Unmarshal(message.Payload, &reqParams); err != nil {
+		if c.transportWS() {
+			c.close(invalidMessageCode, "invalid json")
+			return false
+		}
 		c.sendConnectionError("invalid json")
 		return false
 	}
 
-	doc, qErr := query.Parse(reqParams.Query)
-	if qErr != nil {
-		c.sendError(message.ID, qErr)
-		return true
+	if c.transportWS() {
+		c.mu.Lock()
+		_, exists := c.active[message.ID]
+		c.mu.Unlock()
+		if exists {
+			c.close(subscriberExistsCode, fmt.Sprintf("Subscriber for %s already exists", message.ID))
+			return false
+		}
 	}
 
-	errs := validation.Validate(c.exec.Schema(), doc)
+	doc, op, errs := c.cfg.prepare(c.ctx, c.exec, &reqParams)
 	if len(errs) != 0 {
 		c.sendError(message.ID, errs...)
 		return true
 	}
 
-	op, err := doc.GetOperation(reqParams.OperationName)
-	if err != nil {
-		c.sendError(message.ID, errors.Errorf("%s", err.Error()))
-		return true
-	}
-
 	reqCtx := c.cfg.newRequestContext(doc, reqParams.Query, reqParams.Variables)
-	ctx := graphql.WithRequestContext(c.ctx, reqCtx)
+	ctx, cancel := context.WithCancel(graphql.WithRequestContext(c.ctx, reqCtx))
+	operation := c.start(message.ID, cancel)
+	if operation == nil {
+		cancel()
+		return false
+	}
 
 	if op.Type != query.Subscription {
+		reqCtx.IncrementalDelivery = true
+
 		var result *graphql.Response
 		if op.Type == query.Query {
 			result = c.exec.Query(ctx, op)
@@ -165,39 +397,114 @@ func (c *wsConnection) subscribe(message *operationMessage) bool {
 			result = c.exec.Mutation(ctx, op)
 		}
 
+		if !reqCtx.HasNext() {
+			c.sendData(message.ID, result)
+			c.finish(message.ID, operation)
+			return true
+		}
+
+		initialResponse(reqCtx, result)
 		c.sendData(message.ID, result)
-		c.write(&operationMessage{ID: message.ID, Type: completeMsg})
+
+		go func() {
+			defer c.finish(message.ID, operation)
+			defer c.recoverOperation(ctx, reqCtx, message.ID, operation)
+
+			for patch := reqCtx.NextPatch(); patch != nil && ctx.Err() == nil; patch = reqCtx.NextPatch() {
+				c.sendData(message.ID, patch)
+			}
+		}()
 		return true
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	c.mu.Lock()
-	c.active[message.ID] = cancel
-	c.mu.Unlock()
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				userErr := reqCtx.Recover(ctx, r)
-				c.sendError(message.ID, &errors.QueryError{Message: userErr.Error()})
-			}
-		}()
+		defer c.finish(message.ID, operation)
+		defer c.recoverOperation(ctx, reqCtx, message.ID, operation)
+
 		next := c.exec.Subscription(ctx, op)
-		for result := next(); result != nil; result = next() {
+		for result := next(); result != nil && ctx.Err() == nil; result = next() {
 			c.sendData(message.ID, result)
 		}
+	}()
+
+	return true
+}
 
-		c.write(&operationMessage{ID: message.ID, Type: completeMsg})
+// start registers an operation so that it can be stopped by the client or a shutdown, returning nil once the
+// connection is shutting down.
+func (c *wsConnection) start(id string, cancel context.CancelFunc) *wsOperation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-		c.mu.Lock()
-		delete(c.active, message.ID)
-		c.mu.Unlock()
-		cancel()
-	}()
+	if c.closing {
+		return nil
+	}
+	operation := &wsOperation{cancel: cancel}
+	c.active[id] = operation
+	c.running.Add(1)
+	return operation
+}
 
+// finish ends an operation once it has stopped sending results. This is the only place complete is sent from, so it is
+// always the last message of an operation, and it is not sent if the client has already completed the operation.
+func (c *wsConnection) finish(id string, operation *wsOperation) {
+	if c.remove(id, operation) {
+		c.write(&operationMessage{ID: id, Type: completeMsg})
+	}
+	operation.cancel()
+	c.running.Done()
+}
+
+// recoverOperation sends a panic in an operation to the client as an error, ending the operation without complete.
+func (c *wsConnection) recoverOperation(ctx context.Context, reqCtx *graphql.RequestContext, id string, operation *wsOperation) {
+	if r := recover(); r != nil {
+		userErr := reqCtx.Recover(ctx, r)
+		if c.remove(id, operation) {
+			c.sendError(id, &errors.QueryError{Message: userErr.Error()})
+		}
+	}
+}
+
+// remove unregisters an operation, returning false if it has already been removed. The id may have been reused by
+// the client for a new operation once the old one was completed, which is left running.
+func (c *wsConnection) remove(id string, operation *wsOperation) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.active[id] != operation {
+		return false
+	}
+	delete(c.active, id)
 	return true
 }
 
-func (c *wsConnection) sendData(id string, response *graphql.Response) {
+// operations returns the number of running subscriptions, and queries that are still sending patches
+func (c *wsConnection) operations() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.active)
+}
+
+// shutdown cancels every running operation, waits for each of them to send its complete and closes the connection.
+// No new operations are started once it has been called.
+func (c *wsConnection) shutdown() {
+	c.mu.Lock()
+	c.closing = true
+	var operations []*wsOperation
+	for _, operation := range c.active {
+		operations = append(operations, operation)
+	}
+	c.mu.Unlock()
+
+	for _, operation := range operations {
+		operation.cancel()
+	}
+	c.running.Wait()
+	c.close(websocket.CloseGoingAway, "server shutting down")
+}
+
+func (c *wsConnection) sendData(id string, response interface{}) {
 	b, err := json.Marshal(response)
 	if err != nil {
 		c.sendError(id, errors.Errorf("unable to encode json response: %s", err.Error()))
@@ -231,6 +538,10 @@ func (c *wsConnection) sendConnectionError(format string, args ...interface{}) {
 func (c *wsConnection) readOp() *operationMessage {
 	message := operationMessage{}
 	if err := c.conn.ReadJSON(&message); err != nil {
+		if c.transportWS() {
+			c.close(invalidMessageCode, "invalid json")
+			return nil
+		}
 		c.sendConnectionError("invalid json")
 		return nil
 	}
@@ -238,8 +549,8 @@ func (c *wsConnection) readOp() *operationMessage {
 }
 
 func (c *wsConnection) close(closeCode int, message string) {
-	c.mu.Lock()
+	c.writeMu.Lock()
 	_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, message))
-	c.mu.Unlock()
+	c.writeMu.Unlock()
 	_ = c.conn.Close()
 }
@@ -1,13 +1,21 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/query"
 )
 
 func TestWebsocket(t *testing.T) {
@@ -119,6 +127,351 @@ func TestWebsocket(t *testing.T) {
 	})
 }
 
+func TestWebsocketTransportWS(t *testing.T) {
+	h := GraphQL(&executableSchemaStub{})
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	t.Run("server negotiates graphql-transport-ws", func(t *testing.T) {
+		c, resp, err := websocket.DefaultDialer.Dial(strings.Replace(srv.URL, "http://", "ws://", -1), http.Header{
+			"Sec-Websocket-Protocol": []string{"graphql-transport-ws"},
+		})
+		require.NoError(t, err)
+		defer c.Close()
+
+		require.Equal(t, "graphql-transport-ws", resp.Header.Get("Sec-Websocket-Protocol"))
+	})
+
+	t.Run("client must send init first", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: subscribeMsg, ID: "test_1"}))
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, unauthorizedCode, err.(*websocket.CloseError).Code)
+	})
+
+	t.Run("server responds to ping before init", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: pingMsg}))
+		require.Equal(t, pongMsg, readOp(c).Type)
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+	})
+
+	t.Run("client can only init once", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, tooManyInitialiseCode, err.(*websocket.CloseError).Code)
+	})
+
+	t.Run("server responds to ping", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: pingMsg, Payload: json.RawMessage(`{"a":1}`)}))
+
+		msg := readOp(c)
+		require.Equal(t, pongMsg, msg.Type)
+		require.Equal(t, `{"a":1}`, string(msg.Payload))
+	})
+
+	t.Run("client can receive data", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+
+		require.NoError(t, c.WriteJSON(&operationMessage{
+			Type:    subscribeMsg,
+			ID:      "test_1",
+			Payload: json.RawMessage(`{"query": "subscription { user { title } }"}`),
+		}))
+
+		msg := readOp(c)
+		require.Equal(t, nextMsg, msg.Type)
+		require.Equal(t, "test_1", msg.ID)
+		require.Equal(t, `{"data":{"name":"test"}}`, string(msg.Payload))
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: completeMsg, ID: "test_1"}))
+
+		// the server must not complete an operation the client has completed, anything sent before the pong will be
+		// data that was already in flight
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: pingMsg}))
+		for msg = readOp(c); msg.Type != pongMsg; msg = readOp(c) {
+			require.Equal(t, nextMsg, msg.Type)
+		}
+	})
+
+	t.Run("queries are completed by the server", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+
+		require.NoError(t, c.WriteJSON(&operationMessage{
+			Type:    subscribeMsg,
+			ID:      "test_1",
+			Payload: json.RawMessage(`{"query": "{ me { name } }"}`),
+		}))
+
+		msg := readOp(c)
+		require.Equal(t, nextMsg, msg.Type)
+		require.Equal(t, `{"data":{"name":"test"}}`, string(msg.Payload))
+		require.Equal(t, completeMsg, readOp(c).Type)
+	})
+
+	t.Run("subscription ids must be unique", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, c.WriteJSON(&operationMessage{
+				Type:    subscribeMsg,
+				ID:      "test_1",
+				Payload: json.RawMessage(`{"query": "subscription { user { title } }"}`),
+			}))
+		}
+
+		for {
+			_, _, err := c.ReadMessage()
+			if err != nil {
+				require.Equal(t, subscriberExistsCode, err.(*websocket.CloseError).Code)
+				break
+			}
+		}
+	})
+}
+
+// deferSchemaStub answers queries with the name of the user deferred, its patch is only sent once release is closed
+// or the operation is cancelled.
+type deferSchemaStub struct {
+	executableSchemaStub
+	release chan struct{}
+}
+
+func (e *deferSchemaStub) Query(ctx context.Context, op *query.Operation) *graphql.Response {
+	out := &graphql.OrderedMap{}
+	out.Add("name", graphql.WriterFunc(func(w io.Writer) {
+		select {
+		case <-e.release:
+		case <-ctx.Done():
+		}
+		graphql.MarshalString("test").MarshalGQL(w)
+	}))
+
+	fields := []graphql.CollectedField{{Alias: "name", Name: "name", Defer: &graphql.DeferDirective{}}}
+	var buf bytes.Buffer
+	graphql.GetRequestContext(ctx).IncrementalFields(ctx, fields, out).MarshalGQL(&buf)
+	return &graphql.Response{Data: buf.Bytes()}
+}
+
+func TestWebsocketDeferredQueries(t *testing.T) {
+	stub := &deferSchemaStub{release: make(chan struct{})}
+	srv := httptest.NewServer(GraphQL(stub))
+	defer srv.Close()
+
+	t.Run("query ids must be unique while patches are pending", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, c.WriteJSON(&operationMessage{
+				Type:    subscribeMsg,
+				ID:      "test_1",
+				Payload: json.RawMessage(`{"query": "{ me { ... @defer { name } } }"}`),
+			}))
+		}
+
+		msg := readOp(c)
+		require.Equal(t, nextMsg, msg.Type)
+		require.Equal(t, `{"data":{},"hasNext":true}`, string(msg.Payload))
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, subscriberExistsCode, err.(*websocket.CloseError).Code)
+	})
+
+	t.Run("complete is sent after the patches", func(t *testing.T) {
+		released := &deferSchemaStub{release: make(chan struct{})}
+		close(released.release)
+		srv := httptest.NewServer(GraphQL(released))
+		defer srv.Close()
+
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+		require.NoError(t, c.WriteJSON(&operationMessage{
+			Type:    subscribeMsg,
+			ID:      "test_1",
+			Payload: json.RawMessage(`{"query": "{ me { ... @defer { name } } }"}`),
+		}))
+
+		require.Equal(t, `{"data":{},"hasNext":true}`, string(readOp(c).Payload))
+		require.Equal(t, `{"data":{"name":"test"},"path":[],"hasNext":false}`, string(readOp(c).Payload))
+		require.Equal(t, completeMsg, readOp(c).Type)
+	})
+}
+
+func TestWebsocketInitTimeout(t *testing.T) {
+	srv := httptest.NewServer(GraphQL(&executableSchemaStub{}, WebsocketInitTimeout(10*time.Millisecond)))
+	defer srv.Close()
+
+	t.Run("graphql-ws", func(t *testing.T) {
+		c := wsConnect(srv.URL)
+		defer c.Close()
+
+		msg := readOp(c)
+		require.Equal(t, connectionErrorMsg, msg.Type)
+		require.Equal(t, `{"message":"connection initialisation timeout"}`, string(msg.Payload))
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, websocket.CloseNormalClosure, err.(*websocket.CloseError).Code)
+	})
+
+	t.Run("graphql-transport-ws", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, initTimeoutCode, err.(*websocket.CloseError).Code)
+	})
+
+	t.Run("initialised connections stay open", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: pingMsg}))
+		require.Equal(t, pongMsg, readOp(c).Type)
+	})
+}
+
+func TestWebsocketKeepAlive(t *testing.T) {
+	srv := httptest.NewServer(GraphQL(&executableSchemaStub{}, WebsocketKeepAliveInterval(10*time.Millisecond)))
+	defer srv.Close()
+
+	t.Run("graphql-ws", func(t *testing.T) {
+		c := wsConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+		require.Equal(t, connectionKeepAliveMsg, readOp(c).Type)
+		require.Equal(t, connectionKeepAliveMsg, readOp(c).Type)
+	})
+
+	t.Run("graphql-transport-ws", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+		require.Equal(t, pingMsg, readOp(c).Type)
+		require.Equal(t, pingMsg, readOp(c).Type)
+	})
+}
+
+type userKey struct{}
+
+// userSchemaStub responds to queries with the user stored in the context
+type userSchemaStub struct {
+	executableSchemaStub
+}
+
+func (e *userSchemaStub) Query(ctx context.Context, op *query.Operation) *graphql.Response {
+	return &graphql.Response{Data: []byte(fmt.Sprintf(`{"name":%q}`, ctx.Value(userKey{})))}
+}
+
+func TestWebsocketInitFunc(t *testing.T) {
+	h := GraphQL(&userSchemaStub{}, WebsocketInit(func(ctx context.Context, initPayload InitPayload) (context.Context, error) {
+		if initPayload.GetString("token") != "secret" {
+			return nil, fmt.Errorf("invalid token")
+		}
+		return context.WithValue(ctx, userKey{}, "bob"), nil
+	}))
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	t.Run("accepted connections pass their context to operations", func(t *testing.T) {
+		c := wsConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg, Payload: json.RawMessage(`{"token":"secret"}`)}))
+		require.Equal(t, connectionAckMsg, readOp(c).Type)
+
+		require.NoError(t, c.WriteJSON(&operationMessage{
+			Type:    startMsg,
+			ID:      "test_1",
+			Payload: json.RawMessage(`{"query": "{ me { name } }"}`),
+		}))
+
+		msg := readOp(c)
+		require.Equal(t, dataMsg, msg.Type)
+		require.Equal(t, `{"data":{"name":"bob"}}`, string(msg.Payload))
+	})
+
+	t.Run("graphql-ws connections are rejected with connection_error", func(t *testing.T) {
+		c := wsConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg, Payload: json.RawMessage(`{"token":"wrong"}`)}))
+
+		msg := readOp(c)
+		require.Equal(t, connectionErrorMsg, msg.Type)
+		require.Equal(t, `{"message":"invalid token"}`, string(msg.Payload))
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, websocket.CloseNormalClosure, err.(*websocket.CloseError).Code)
+	})
+
+	t.Run("graphql-transport-ws connections are closed as forbidden", func(t *testing.T) {
+		c := wsTransportConnect(srv.URL)
+		defer c.Close()
+
+		require.NoError(t, c.WriteJSON(&operationMessage{Type: connectionInitMsg}))
+
+		_, _, err := c.ReadMessage()
+		require.Equal(t, forbiddenCode, err.(*websocket.CloseError).Code)
+		require.Equal(t, "invalid token", err.(*websocket.CloseError).Text)
+	})
+}
+
+func wsTransportConnect(url string) *websocket.Conn {
+	dialer := websocket.Dialer{Subprotocols: []string{graphqltransportwsSubprotocol}}
+	c, _, err := dialer.Dial(strings.Replace(url, "http://", "ws://", -1), nil)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
 func wsConnect(url string) *websocket.Conn {
 	c, _, err := websocket.DefaultDialer.Dial(strings.Replace(url, "http://", "ws://", -1), nil)
 	if err != nil {
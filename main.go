@@ -18,6 +18,8 @@ var schemaFilename = flag.String("schema", "", "the graphql schema to generate t
 var typemap = flag.String("typemap", "", "a json map going from graphql to golang types")
 var packageName = flag.String("package", "", "the package name")
 var modelPackageName = flag.String("modelpackage", "", "the package name to use for models")
+var generateClient = flag.Bool("client", false, "generate the typed client from the client section of the config instead of a server")
+var check = flag.Bool("check", false, "exit with an error if any generated file is out of date, instead of writing it")
 var help = flag.Bool("h", false, "this usage text")
 var verbose = flag.Bool("v", false, "show logs")
 
@@ -54,7 +56,7 @@ func main() {
 	// overwrite by commandline options
 	var emitYamlGuidance bool
 	if *schemaFilename != "" {
-		config.SchemaFilename = *schemaFilename
+		config.SchemaFilename = codegen.SchemaFilenames{*schemaFilename}
 	}
 	if *models != "" {
 		config.Model.Filename = *models
@@ -73,12 +75,10 @@ func main() {
 		emitYamlGuidance = true
 	}
 
-	schemaRaw, err := ioutil.ReadFile(config.SchemaFilename)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "unable to open schema: "+err.Error())
+	if err = config.LoadSchema(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
-	config.SchemaStr = string(schemaRaw)
 
 	if err = config.Check(); err != nil {
 		fmt.Fprintln(os.Stderr, "invalid config format: "+err.Error())
@@ -96,7 +96,32 @@ func main() {
 		fmt.Fprintf(os.Stderr, "DEPRECATION WARNING: we are moving away from the json typemap, instead create a gqlgen.yml with the following content:\n\n%s\n", string(b))
 	}
 
-	err = codegen.Generate(*config)
+	if *check {
+		var stale []string
+		if *generateClient {
+			stale, err = codegen.StaleClient(*config)
+		} else {
+			stale, err = codegen.Stale(*config)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(2)
+		}
+		if len(stale) > 0 {
+			fmt.Fprintln(os.Stderr, "generated files are out of date, run gqlgen to update them:")
+			for _, filename := range stale {
+				fmt.Fprintln(os.Stderr, "  "+filename)
+			}
+			os.Exit(3)
+		}
+		return
+	}
+
+	if *generateClient {
+		err = codegen.GenerateClient(*config)
+	} else {
+		err = codegen.Generate(*config)
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(2)
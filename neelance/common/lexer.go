@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"strings"
 	"text/scanner"
 
 	"github.com/vektah/gqlgen/neelance/errors"
@@ -12,7 +13,9 @@ type syntaxError string
 type Lexer struct {
 	sc          *scanner.Scanner
 	next        rune
+	text        string
 	descComment string
+	err         *errors.QueryError
 }
 
 type Ident struct {
@@ -20,13 +23,28 @@ type Ident struct {
 	Loc  errors.Location
 }
 
+// New creates a lexer reading from sc and reads the first token. A syntax error in it is returned by the first call
+// to CatchSyntaxError.
 func New(sc *scanner.Scanner) *Lexer {
 	l := &Lexer{sc: sc}
-	l.Consume()
+	if sc.Error == nil {
+		sc.Error = func(_ *scanner.Scanner, msg string) {
+			// the scanner checks strings for go escapes, they are checked against the graphql escapes on use instead
+			if msg != "invalid char escape" {
+				l.SyntaxError(msg)
+			}
+		}
+	}
+	l.err = l.CatchSyntaxError(l.Consume)
 	return l
 }
 
 func (l *Lexer) CatchSyntaxError(f func()) (errRes *errors.QueryError) {
+	if l.err != nil {
+		errRes, l.err = l.err, nil
+		return errRes
+	}
+
 	defer func() {
 		if err := recover(); err != nil {
 			if err, ok := err.(syntaxError); ok {
@@ -71,42 +89,128 @@ func (l *Lexer) Consume() {
 		}
 		break
 	}
+
+	l.text = l.sc.TokenText()
+	if l.next == scanner.String && l.text == `""` && l.sc.Peek() == '"' {
+		l.text = Quote(blockStringValue(l.consumeBlockString()))
+	}
+}
+
+// consumeBlockString reads the raw contents of a block string up to the closing """,
+// the scanner has already read the first two quotes of the opening """ as an empty string.
+func (l *Lexer) consumeBlockString() string {
+	// reading runes with Next clears the position of the token, keep it for error locations
+	start := l.sc.Position
+	defer func() { l.sc.Position = start }()
+	l.sc.Next()
+
+	var raw []rune
+	// quotes before unescaped belong to an escaped \""" and cannot close the string
+	unescaped := 0
+	for {
+		next := l.sc.Next()
+		if next == scanner.EOF {
+			l.SyntaxError("unterminated block string")
+		}
+		raw = append(raw, next)
+
+		n := len(raw)
+		if n-3 < unescaped || string(raw[n-3:]) != `"""` {
+			continue
+		}
+		if n >= 4 && raw[n-4] == '\\' {
+			raw = append(raw[:n-4], '"', '"', '"')
+			unescaped = len(raw)
+			continue
+		}
+		return string(raw[:n-3])
+	}
+}
+
+// blockStringValue strips the common indentation and the leading and trailing blank lines
+// from a block string, as described in the spec.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.Replace(strings.Replace(raw, "\r\n", "\n", -1), "\r", "\n", -1), "\n")
+
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < indent {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][indent:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimLeft(lines[0], " \t") == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimLeft(lines[len(lines)-1], " \t") == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
 }
 
 func (l *Lexer) ConsumeIdent() string {
-	name := l.sc.TokenText()
+	name := l.text
 	l.ConsumeToken(scanner.Ident)
 	return name
 }
 
 func (l *Lexer) ConsumeIdentWithLoc() Ident {
 	loc := l.Location()
-	name := l.sc.TokenText()
+	name := l.text
 	l.ConsumeToken(scanner.Ident)
 	return Ident{name, loc}
 }
 
 func (l *Lexer) ConsumeKeyword(keyword string) {
-	if l.next != scanner.Ident || l.sc.TokenText() != keyword {
-		l.SyntaxError(fmt.Sprintf("unexpected %q, expecting %q", l.sc.TokenText(), keyword))
+	if l.next != scanner.Ident || l.text != keyword {
+		l.SyntaxError(fmt.Sprintf("unexpected %q, expecting %q", l.text, keyword))
 	}
 	l.Consume()
 }
 
 func (l *Lexer) ConsumeLiteral() *BasicLit {
-	lit := &BasicLit{Type: l.next, Text: l.sc.TokenText()}
+	lit := &BasicLit{Type: l.next, Text: l.text}
+	if l.next == scanner.String {
+		if _, err := Unquote(l.text); err != nil {
+			l.SyntaxError(fmt.Sprintf("invalid string %s: %s", l.text, err))
+		}
+	}
 	l.Consume()
 	return lit
 }
 
 func (l *Lexer) ConsumeToken(expected rune) {
 	if l.next != expected {
-		l.SyntaxError(fmt.Sprintf("unexpected %q, expecting %s", l.sc.TokenText(), scanner.TokenString(expected)))
+		l.SyntaxError(fmt.Sprintf("unexpected %q, expecting %s", l.text, scanner.TokenString(expected)))
 	}
 	l.Consume()
 }
 
+// DescComment returns the description of the next definition. A string or block string
+// takes precedence over any comments above the definition.
 func (l *Lexer) DescComment() string {
+	if l.next == scanner.String {
+		desc, err := Unquote(l.text)
+		if err != nil {
+			l.SyntaxError(fmt.Sprintf("invalid description %s: %s", l.text, err))
+		}
+		l.Consume()
+		return desc
+	}
 	return l.descComment
 }
 
@@ -37,7 +37,7 @@ func (lit *BasicLit) Value(vars map[string]interface{}) interface{} {
 		return value
 
 	case scanner.String:
-		value, err := strconv.Unquote(lit.Text)
+		value, err := Unquote(lit.Text)
 		if err != nil {
 			panic(err)
 		}
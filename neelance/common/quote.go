@@ -0,0 +1,106 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Quote renders a string literal. strconv.Quote is no good here, graphql does not have go escapes like \a or \x00.
+func Quote(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(&buf, `\u%04X`, r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// Unquote decodes a string literal. Only the escapes in the graphql spec are accepted, strconv.Unquote would reject
+// \/ and allow go escapes like \x00.
+func Unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string literal %s", s)
+	}
+	s = s[1 : len(s)-1]
+
+	var buf bytes.Buffer
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		switch {
+		case r == '"':
+			return "", fmt.Errorf("unescaped quote in string literal")
+		case r != '\\':
+			buf.WriteRune(r)
+			s = s[size:]
+			continue
+		case len(s) < 2:
+			return "", fmt.Errorf("unterminated escape in string literal")
+		}
+
+		switch s[1] {
+		case '"', '\\', '/':
+			buf.WriteByte(s[1])
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'u':
+			r, err := unquoteUnicode(s)
+			if err != nil {
+				return "", err
+			}
+			s = s[6:]
+			if utf16.IsSurrogate(r) {
+				// characters outside the basic multilingual plane are written as a surrogate pair of escapes
+				low, err := unquoteUnicode(s)
+				if err != nil {
+					return "", fmt.Errorf("invalid surrogate pair in string literal")
+				}
+				if r = utf16.DecodeRune(r, low); r == utf8.RuneError {
+					return "", fmt.Errorf("invalid surrogate pair in string literal")
+				}
+				s = s[6:]
+			}
+			buf.WriteRune(r)
+			continue
+		default:
+			return "", fmt.Errorf("invalid escape \\%c in string literal", s[1])
+		}
+		s = s[2:]
+	}
+	return buf.String(), nil
+}
+
+// unquoteUnicode decodes the \uXXXX escape at the start of s
+func unquoteUnicode(s string) (rune, error) {
+	if len(s) < 6 || s[0] != '\\' || s[1] != 'u' {
+		return 0, fmt.Errorf("invalid unicode escape in string literal")
+	}
+	code, err := strconv.ParseUint(s[2:6], 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid unicode escape \\u%s in string literal", s[2:6])
+	}
+	return rune(code), nil
+}
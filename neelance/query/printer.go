@@ -0,0 +1,199 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/scanner"
+
+	"github.com/vektah/gqlgen/neelance/common"
+)
+
+// Print renders the document back to GraphQL, operations first followed by the fragments.
+func Print(doc *Document) string {
+	p := &printer{}
+
+	var definitions []string
+	for _, op := range doc.Operations {
+		definitions = append(definitions, p.operation(op))
+	}
+	for _, frag := range doc.Fragments {
+		definitions = append(definitions, p.fragment(frag))
+	}
+
+	return strings.Join(definitions, "\n")
+}
+
+// Normalize renders an operation and the fragments it uses as a stable signature. Fields are stored without
+// aliases, selections, arguments and directives are sorted and literal values are stripped, so operations that
+// only differ in formatting, aliases or inline values share the same signature.
+func Normalize(doc *Document, operationName string) (string, error) {
+	op, err := doc.GetOperation(operationName)
+	if err != nil {
+		return "", err
+	}
+	p := &printer{normalize: true}
+
+	fragments := doc.UsedFragments(op.Selections)
+	sort.Slice(fragments, func(i, j int) bool { return fragments[i].Name.Name < fragments[j].Name.Name })
+
+	definitions := []string{p.operation(op)}
+	for _, frag := range fragments {
+		definitions = append(definitions, p.fragment(frag))
+	}
+
+	return strings.Join(definitions, "\n"), nil
+}
+
+type printer struct {
+	normalize bool
+}
+
+func (p *printer) operation(op *Operation) string {
+	if op.Type == Query && op.Name.Name == "" && len(op.Vars) == 0 && len(op.Directives) == 0 {
+		return p.selectionSet(op.Selections, "") + "\n"
+	}
+
+	str := strings.ToLower(string(op.Type))
+	if op.Name.Name != "" {
+		str += " " + op.Name.Name
+	}
+	if len(op.Vars) > 0 {
+		vars := make([]string, len(op.Vars))
+		for i, v := range op.Vars {
+			vars[i] = "$" + v.Name.Name + ": " + typeString(v.Type)
+			if v.Default != nil {
+				vars[i] += " = " + p.literal(v.Default)
+			}
+		}
+		if p.normalize {
+			sort.Strings(vars)
+		}
+		str += "(" + strings.Join(vars, ", ") + ")"
+	}
+	str += p.directives(op.Directives)
+
+	return str + " " + p.selectionSet(op.Selections, "") + "\n"
+}
+
+func (p *printer) fragment(frag *FragmentDecl) string {
+	return fmt.Sprintf("fragment %s on %s%s %s\n", frag.Name.Name, frag.On.Name, p.directives(frag.Directives), p.selectionSet(frag.Selections, ""))
+}
+
+func (p *printer) selectionSet(sels []Selection, indent string) string {
+	type rendered struct {
+		order int
+		name  string
+		text  string
+	}
+
+	var selections []rendered
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			str := sel.Name.Name
+			if !p.normalize && sel.Alias.Name != sel.Name.Name {
+				str = sel.Alias.Name + ": " + str
+			}
+			str += p.arguments(sel.Arguments) + p.directives(sel.Directives)
+			if len(sel.Selections) > 0 {
+				str += " " + p.selectionSet(sel.Selections, indent+"\t")
+			}
+			selections = append(selections, rendered{0, sel.Name.Name, str})
+
+		case *FragmentSpread:
+			str := "..." + sel.Name.Name + p.directives(sel.Directives)
+			selections = append(selections, rendered{1, sel.Name.Name, str})
+
+		case *InlineFragment:
+			str := "..."
+			if sel.On.Name != "" {
+				str += " on " + sel.On.Name
+			}
+			str += p.directives(sel.Directives) + " " + p.selectionSet(sel.Selections, indent+"\t")
+			selections = append(selections, rendered{2, sel.On.Name, str})
+		}
+	}
+
+	if p.normalize {
+		sort.SliceStable(selections, func(i, j int) bool {
+			a, b := selections[i], selections[j]
+			if a.order != b.order {
+				return a.order < b.order
+			}
+			if a.name != b.name {
+				return a.name < b.name
+			}
+			return a.text < b.text
+		})
+	}
+
+	str := "{\n"
+	for _, sel := range selections {
+		str += indent + "\t" + sel.text + "\n"
+	}
+	return str + indent + "}"
+}
+
+func (p *printer) arguments(args common.ArgumentList) string {
+	if len(args) == 0 {
+		return ""
+	}
+	rendered := make([]string, len(args))
+	for i, arg := range args {
+		rendered[i] = arg.Name.Name + ": " + p.literal(arg.Value)
+	}
+	if p.normalize {
+		sort.Strings(rendered)
+	}
+	return "(" + strings.Join(rendered, ", ") + ")"
+}
+
+func (p *printer) directives(directives common.DirectiveList) string {
+	rendered := make([]string, len(directives))
+	for i, d := range directives {
+		rendered[i] = " @" + d.Name.Name + p.arguments(d.Args)
+	}
+	if p.normalize {
+		sort.Strings(rendered)
+	}
+	return strings.Join(rendered, "")
+}
+
+// literal renders a value, when normalizing numbers, strings, lists and objects are replaced by an empty value of
+// the same kind so that the signature does not depend on them.
+func (p *printer) literal(lit common.Literal) string {
+	if !p.normalize {
+		return lit.String()
+	}
+
+	switch lit := lit.(type) {
+	case *common.BasicLit:
+		switch lit.Type {
+		case scanner.Int, scanner.Float:
+			return "0"
+		case scanner.String:
+			return `""`
+		}
+	case *common.ListLit:
+		return "[]"
+	case *common.ObjectLit:
+		return "{}"
+	}
+	return lit.String()
+}
+
+// typeString renders a variable type, these have not been resolved against a schema so they may still contain
+// type names.
+func typeString(t common.Type) string {
+	switch t := t.(type) {
+	case *common.List:
+		return "[" + typeString(t.OfType) + "]"
+	case *common.NonNull:
+		return typeString(t.OfType) + "!"
+	case *common.TypeName:
+		return t.Name
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,126 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const document = `
+	query Hero($episode: Episode = JEDI, $first: Int! = 3, $ids: [ID!]) @cached(ttl: 60) {
+		hero(episode: $episode) {
+			name
+			...Friends @include(if: true)
+			... on Droid {
+				primaryFunction
+			}
+			alias: appearsIn
+		}
+		search(text: """ block """, limit: 10, after: -1.5, filter: {tags: ["a", "b"], near: null}) {
+			__typename
+		}
+	}
+
+	fragment Friends on Character {
+		friends(first: $first) { name }
+	}
+
+	mutation Review { createReview(episode: NEWHOPE, review: {stars: 5}) { stars } }
+
+	{ unused }
+`
+
+func TestPrint(t *testing.T) {
+	doc, err := Parse(document)
+	require.Nil(t, err)
+
+	expected := `query Hero($episode: Episode = JEDI, $first: Int! = 3, $ids: [ID!]) @cached(ttl: 60) {
+	hero(episode: $episode) {
+		name
+		...Friends @include(if: true)
+		... on Droid {
+			primaryFunction
+		}
+		alias: appearsIn
+	}
+	search(text: " block ", limit: 10, after: -1.5, filter: {tags: ["a", "b"], near: null}) {
+		__typename
+	}
+}
+
+mutation Review {
+	createReview(episode: NEWHOPE, review: {stars: 5}) {
+		stars
+	}
+}
+
+{
+	unused
+}
+
+fragment Friends on Character {
+	friends(first: $first) {
+		name
+	}
+}
+`
+	require.Equal(t, expected, Print(doc))
+
+	t.Run("printing is idempotent", func(t *testing.T) {
+		reparsed, err := Parse(expected)
+		require.Nil(t, err)
+		require.Equal(t, expected, Print(reparsed))
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	doc, parseErr := Parse(document)
+	require.Nil(t, parseErr)
+
+	signature, err := Normalize(doc, "Hero")
+	require.NoError(t, err)
+	require.Equal(t, `query Hero($episode: Episode = JEDI, $first: Int! = 0, $ids: [ID!]) @cached(ttl: 0) {
+	hero(episode: $episode) {
+		appearsIn
+		name
+		...Friends @include(if: true)
+		... on Droid {
+			primaryFunction
+		}
+	}
+	search(after: 0, filter: {}, limit: 0, text: "") {
+		__typename
+	}
+}
+
+fragment Friends on Character {
+	friends(first: $first) {
+		name
+	}
+}
+`, signature)
+
+	t.Run("equivalent operations share a signature", func(t *testing.T) {
+		a, parseErr := Parse(`query Q($b: Int, $a: String) { user(id: 1, name: "bob") { id ...F } } fragment F on User { name }`)
+		require.Nil(t, parseErr)
+		b, parseErr := Parse(`
+			fragment Unused on User { id }
+			fragment F on User { name }
+			query Q($a: String, $b: Int) {
+				user(name: "alice", id: 2) { ...F, identifier: id }
+			}
+		`)
+		require.Nil(t, parseErr)
+
+		sigA, err := Normalize(a, "")
+		require.NoError(t, err)
+		sigB, err := Normalize(b, "")
+		require.NoError(t, err)
+		require.Equal(t, sigA, sigB)
+	})
+
+	t.Run("unknown operations are errors", func(t *testing.T) {
+		_, err := Normalize(doc, "Missing")
+		require.EqualError(t, err, `no operation with name "Missing"`)
+	})
+}
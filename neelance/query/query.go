@@ -165,6 +165,7 @@ func parseOperation(l *common.Lexer, opType OperationType) *Operation {
 		}
 		l.ConsumeToken(')')
 	}
+	op.Directives = append(op.Directives, common.ParseDirectives(l)...)
 	op.Selections = parseSelectionSet(l)
 	return op
 }
@@ -259,3 +260,36 @@ func (d *Document) GetOperation(operationName string) (*Operation, error) {
 	}
 	return op, nil
 }
+
+// UsedFragments returns the fragments used by the selections, directly or through other fragments, in document order.
+func (d *Document) UsedFragments(sels []Selection) FragmentList {
+	used := map[string]bool{}
+	var collect func(sels []Selection)
+	collect = func(sels []Selection) {
+		for _, sel := range sels {
+			switch sel := sel.(type) {
+			case *Field:
+				collect(sel.Selections)
+			case *InlineFragment:
+				collect(sel.Selections)
+			case *FragmentSpread:
+				if used[sel.Name.Name] {
+					continue
+				}
+				used[sel.Name.Name] = true
+				if frag := d.Fragments.Get(sel.Name.Name); frag != nil {
+					collect(frag.Selections)
+				}
+			}
+		}
+	}
+	collect(sels)
+
+	var fragments FragmentList
+	for _, frag := range d.Fragments {
+		if used[frag.Name.Name] {
+			fragments = append(fragments, frag)
+		}
+	}
+	return fragments
+}
@@ -0,0 +1,26 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStringLiterals(t *testing.T) {
+	doc, err := Parse(`{
+		search(text: """
+			multi
+			  line
+		""", exact: "")
+	}`)
+	require.Nil(t, err)
+
+	field := doc.Operations[0].Selections[0].(*Field)
+	require.Equal(t, "multi\n  line", field.Arguments.MustGet("text").Value(nil))
+	require.Equal(t, "", field.Arguments.MustGet("exact").Value(nil))
+
+	t.Run("unterminated block strings are syntax errors", func(t *testing.T) {
+		_, err := Parse(`"""never closed`)
+		require.EqualError(t, err, "graphql: syntax error: unterminated block string (line 1, column 1)")
+	})
+}
@@ -41,6 +41,24 @@ var metaSrc = `
 		if: Boolean!
 	) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 
+	# Directs the executor to deliver this fragment after the rest of the response, when the transport supports it.
+	directive @defer(
+		# Deferred when true.
+		if: Boolean = true
+		# Identifies the patch holding the fragment.
+		label: String
+	) on FRAGMENT_SPREAD | INLINE_FRAGMENT
+
+	# Directs the executor to deliver the items of this list after the rest of the response, when the transport supports it.
+	directive @stream(
+		# Streamed when true.
+		if: Boolean = true
+		# Identifies the patches holding the streamed items.
+		label: String
+		# The number of items to include in the initial response.
+		initialCount: Int = 0
+	) on FIELD
+
 	# Marks an element of a GraphQL schema as no longer supported.
 	directive @deprecated(
 		# Explains why this element was deprecated, usually also including a suggestion
@@ -0,0 +1,236 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlgen/neelance/common"
+)
+
+// Print renders the schema back to SDL. The output is canonical: definitions, fields, arguments, enum values,
+// interfaces and union members are sorted by name, and the builtin types and directives are left out, so two
+// schemas declaring the same things in a different order or across different files print identically.
+func Print(s *Schema) string {
+	p := &printer{}
+
+	p.schema(s)
+
+	var directives []*DirectiveDecl
+	for name, d := range s.Directives {
+		if Meta.Directives[name] != d {
+			directives = append(directives, d)
+		}
+	}
+	sort.Slice(directives, func(i, j int) bool { return directives[i].Name < directives[j].Name })
+	for _, d := range directives {
+		p.directiveDecl(d)
+	}
+
+	var types []NamedType
+	for name, t := range s.Types {
+		if Meta.Types[name] != t {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].TypeName() < types[j].TypeName() })
+	for _, t := range types {
+		p.namedType(t)
+	}
+
+	return p.buf.String()
+}
+
+type printer struct {
+	buf bytes.Buffer
+}
+
+func (p *printer) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&p.buf, format, args...)
+}
+
+// definition starts a new top level definition, separated from the previous one by a blank line.
+func (p *printer) definition(desc string) {
+	if p.buf.Len() > 0 {
+		p.buf.WriteString("\n")
+	}
+	p.description(desc, "")
+}
+
+func (p *printer) schema(s *Schema) {
+	custom := false
+	for name, t := range s.EntryPoints {
+		if defaultEntrypoints[name] != t.TypeName() {
+			custom = true
+		}
+	}
+	if !custom {
+		return
+	}
+
+	p.definition("")
+	p.printf("schema {\n")
+	for _, name := range []string{"query", "mutation", "subscription"} {
+		if t, ok := s.EntryPoints[name]; ok {
+			p.printf("\t%s: %s\n", name, t.TypeName())
+		}
+	}
+	p.printf("}\n")
+}
+
+func (p *printer) directiveDecl(d *DirectiveDecl) {
+	p.definition(d.Desc)
+	p.printf("directive @%s%s on %s\n", d.Name, p.args(d.Args, ""), strings.Join(d.Locs, " | "))
+}
+
+func (p *printer) namedType(t NamedType) {
+	p.definition(t.Description())
+
+	switch t := t.(type) {
+	case *Scalar:
+		p.printf("scalar %s\n", t.Name)
+
+	case *Object:
+		var interfaces []string
+		for _, intf := range t.Interfaces {
+			interfaces = append(interfaces, intf.Name)
+		}
+		sort.Strings(interfaces)
+
+		p.printf("type %s", t.Name)
+		if len(interfaces) > 0 {
+			p.printf(" implements %s", strings.Join(interfaces, " & "))
+		}
+		p.printf("%s", directives(t.Directives))
+		p.fields(t.Fields)
+
+	case *Interface:
+		p.printf("interface %s%s", t.Name, directives(t.Directives))
+		p.fields(t.Fields)
+
+	case *Union:
+		var members []string
+		for _, obj := range t.PossibleTypes {
+			members = append(members, obj.Name)
+		}
+		sort.Strings(members)
+		p.printf("union %s = %s\n", t.Name, strings.Join(members, " | "))
+
+	case *Enum:
+		values := make([]*EnumValue, len(t.Values))
+		copy(values, t.Values)
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+
+		p.printf("enum %s {\n", t.Name)
+		for _, v := range values {
+			p.description(v.Desc, "\t")
+			p.printf("\t%s%s\n", v.Name, directives(v.Directives))
+		}
+		p.printf("}\n")
+
+	case *InputObject:
+		p.printf("input %s {\n", t.Name)
+		for _, v := range sortInputValues(t.Values) {
+			p.description(v.Desc, "\t")
+			p.printf("\t%s\n", inputValue(v))
+		}
+		p.printf("}\n")
+	}
+}
+
+func (p *printer) fields(list FieldList) {
+	fields := make(FieldList, len(list))
+	copy(fields, list)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	p.printf(" {\n")
+	for _, f := range fields {
+		p.description(f.Desc, "\t")
+		p.printf("\t%s%s: %s%s\n", f.Name, p.args(f.Args, "\t"), f.Type.String(), directives(f.Directives))
+	}
+	p.printf("}\n")
+}
+
+// args renders an argument list, arguments with descriptions are placed on their own lines.
+func (p *printer) args(list common.InputValueList, indent string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	args := sortInputValues(list)
+
+	multiline := false
+	for _, arg := range args {
+		if arg.Desc != "" {
+			multiline = true
+		}
+	}
+
+	if !multiline {
+		rendered := make([]string, len(args))
+		for i, arg := range args {
+			rendered[i] = inputValue(arg)
+		}
+		return "(" + strings.Join(rendered, ", ") + ")"
+	}
+
+	argPrinter := &printer{}
+	argPrinter.buf.WriteString("(\n")
+	for _, arg := range args {
+		argPrinter.description(arg.Desc, indent+"\t")
+		argPrinter.printf("%s\t%s\n", indent, inputValue(arg))
+	}
+	argPrinter.printf("%s)", indent)
+	return argPrinter.buf.String()
+}
+
+// description renders a description as a string, or as a block string if it spans multiple lines.
+func (p *printer) description(desc string, indent string) {
+	if desc == "" {
+		return
+	}
+	if !strings.Contains(desc, "\n") {
+		p.printf("%s%s\n", indent, common.Quote(desc))
+		return
+	}
+
+	p.printf("%s\"\"\"\n", indent)
+	for _, line := range strings.Split(strings.Replace(desc, `"""`, `\"""`, -1), "\n") {
+		if line == "" {
+			p.printf("\n")
+		} else {
+			p.printf("%s%s\n", indent, line)
+		}
+	}
+	p.printf("%s\"\"\"\n", indent)
+}
+
+func inputValue(v *common.InputValue) string {
+	str := v.Name.Name + ": " + v.Type.String()
+	if v.Default != nil {
+		str += " = " + v.Default.String()
+	}
+	return str
+}
+
+func directives(list common.DirectiveList) string {
+	var str string
+	for _, d := range list {
+		str += " @" + d.Name.Name
+		if len(d.Args) > 0 {
+			args := make([]string, len(d.Args))
+			for i, arg := range d.Args {
+				args[i] = arg.Name.Name + ": " + arg.Value.String()
+			}
+			str += "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return str
+}
+
+func sortInputValues(list common.InputValueList) common.InputValueList {
+	sorted := make(common.InputValueList, len(list))
+	copy(sorted, list)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name.Name < sorted[j].Name.Name })
+	return sorted
+}
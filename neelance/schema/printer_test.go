@@ -0,0 +1,177 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	s := MustParse(`
+		schema {
+			query: RootQuery
+			mutation: RootMutation
+		}
+
+		type RootQuery {
+			# comments are descriptions too
+			things(limit: Int = 10, after: ID): [Thing!]! @deprecated(reason: "use search")
+			search(
+				"""
+				The text to search for,
+				  "quoted" or not
+				"""
+				text: String!
+				"Which kinds of thing to search"
+				kinds: [Kind!] = [SMALL, LARGE]
+				filter: Filter = {sizeAtLeast: 2}
+			): [Result]
+		}
+
+		type RootMutation {
+			addThing(thing: Filter!): Thing
+		}
+
+		"Something with a size"
+		interface Sized {
+			size: Int!
+		}
+
+		interface Named @entity {
+			name: String
+		}
+
+		"""A thing, sized and named"""
+		type Thing implements Sized & Named @entity {
+			size: Int!
+			name: String
+		}
+
+		type Other implements Named {
+			name: String
+		}
+
+		union Result = Thing | Other
+
+		enum Kind {
+			SMALL
+			"use SMALL"
+			TINY @deprecated
+			LARGE
+		}
+
+		input Filter {
+			sizeAtLeast: Int = -1
+			name: String = """ block """
+		}
+
+		scalar Time
+
+		"""
+		Marks a type as an entity
+		"""
+		directive @entity(key: String = "id") on OBJECT | INTERFACE
+	`)
+
+	expected := `schema {
+	query: RootQuery
+	mutation: RootMutation
+}
+
+"Marks a type as an entity"
+directive @entity(key: String = "id") on OBJECT | INTERFACE
+
+input Filter {
+	name: String = " block "
+	sizeAtLeast: Int = -1
+}
+
+enum Kind {
+	LARGE
+	SMALL
+	"use SMALL"
+	TINY @deprecated(reason: "No longer supported")
+}
+
+interface Named @entity(key: "id") {
+	name: String
+}
+
+type Other implements Named {
+	name: String
+}
+
+union Result = Other | Thing
+
+type RootMutation {
+	addThing(thing: Filter!): Thing
+}
+
+type RootQuery {
+	search(
+		filter: Filter = {sizeAtLeast: 2}
+		"Which kinds of thing to search"
+		kinds: [Kind!] = [SMALL, LARGE]
+		"""
+		The text to search for,
+		  "quoted" or not
+		"""
+		text: String!
+	): [Result]
+	"comments are descriptions too"
+	things(after: ID, limit: Int = 10): [Thing!]! @deprecated(reason: "use search")
+}
+
+"Something with a size"
+interface Sized {
+	size: Int!
+}
+
+"A thing, sized and named"
+type Thing implements Named & Sized @entity(key: "id") {
+	name: String
+	size: Int!
+}
+
+scalar Time
+`
+	require.Equal(t, expected, Print(s))
+
+	t.Run("printing is idempotent", func(t *testing.T) {
+		require.Equal(t, expected, Print(MustParse(expected)))
+	})
+
+	t.Run("declaration order does not matter", func(t *testing.T) {
+		a := MustParse(`
+			type Query { b: Int a(y: Int, x: Int): Int }
+			extend type Query { c: String }
+			enum E { Y X }
+		`)
+		b := MustParse(`
+			enum E { X Y }
+			type Query { c: String a(x: Int, y: Int): Int b: Int }
+		`)
+		require.Equal(t, Print(a), Print(b))
+	})
+
+	t.Run("default entry points are implied", func(t *testing.T) {
+		require.Equal(t, "type Query {\n\ta: Int\n}\n", Print(MustParse(`schema { query: Query } type Query { a: Int }`)))
+	})
+
+	t.Run("descriptions round trip", func(t *testing.T) {
+		for _, desc := range []string{
+			`quotes " and \ backslashes`,
+			"tab\tand bell\a and nul\x00",
+			"carriage\rreturn",
+			"unicode é ✓ 😀 and del \x7f",
+		} {
+			s := New()
+			s.Types["Query"] = &Object{Name: "Query", Desc: desc, Fields: FieldList{{Name: "a", Type: s.Types["Int"]}}}
+			printed := Print(s)
+			require.NotContains(t, printed, `\a`)
+			require.NotContains(t, printed, `\x`)
+
+			require.Equal(t, desc, MustParse(printed).Types["Query"].Description(), printed)
+		}
+	})
+}
@@ -18,6 +18,7 @@ type Schema struct {
 	objects         []*Object
 	unions          []*Union
 	enums           []*Enum
+	extensions      []NamedType
 }
 
 var defaultEntrypoints = map[string]string{
@@ -45,6 +46,7 @@ type Object struct {
 	Name       string
 	Interfaces []*Interface
 	Fields     FieldList
+	Directives common.DirectiveList
 	Desc       string
 
 	interfaceNames []string
@@ -54,6 +56,7 @@ type Interface struct {
 	Name          string
 	PossibleTypes []*Object
 	Fields        FieldList
+	Directives    common.DirectiveList
 	Desc          string
 }
 
@@ -170,16 +173,11 @@ func New() *Schema {
 }
 
 func (s *Schema) Parse(schemaString string) error {
-	sc := &scanner.Scanner{
-		Mode: scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings,
+	if err := parseSyntax(s, schemaString); err != nil {
+		return err
 	}
-	sc.Init(strings.NewReader(schemaString))
 
-	l := common.New(sc)
-	err := l.CatchSyntaxError(func() {
-		parseSchema(s, l)
-	})
-	if err != nil {
+	if err := applyExtensions(s); err != nil {
 		return err
 	}
 
@@ -263,6 +261,93 @@ func (s *Schema) Parse(schemaString string) error {
 	return nil
 }
 
+// ParseSyntax only checks the syntax of a schema. The types it refers to are not resolved, so each file of a schema
+// that has been split up can be checked on its own.
+func ParseSyntax(schemaString string) *errors.QueryError {
+	return parseSyntax(New(), schemaString)
+}
+
+func parseSyntax(s *Schema, schemaString string) *errors.QueryError {
+	sc := &scanner.Scanner{
+		Mode: scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings,
+	}
+	sc.Init(strings.NewReader(schemaString))
+
+	l := common.New(sc)
+	return l.CatchSyntaxError(func() {
+		parseSchema(s, l)
+	})
+}
+
+// applyExtensions merges each extension into the type it extends. Extending a type that has not been declared
+// declares it, so a service can extend types that are owned by another service.
+func applyExtensions(s *Schema) error {
+	for _, ext := range s.extensions {
+		t, ok := s.Types[ext.TypeName()]
+		if !ok {
+			s.Types[ext.TypeName()] = ext
+			switch ext := ext.(type) {
+			case *Object:
+				s.objects = append(s.objects, ext)
+			case *Enum:
+				s.enums = append(s.enums, ext)
+			}
+			continue
+		}
+		if t.Kind() != ext.Kind() {
+			return errors.Errorf("cannot extend %q as %s, it is %s", ext.TypeName(), ext.Kind(), t.Kind())
+		}
+
+		switch t := t.(type) {
+		case *Object:
+			ext := ext.(*Object)
+			fields, err := mergeFields(t.Name, t.Fields, ext.Fields)
+			if err != nil {
+				return err
+			}
+			t.Fields = fields
+			t.interfaceNames = append(t.interfaceNames, ext.interfaceNames...)
+			t.Directives = append(t.Directives, ext.Directives...)
+		case *Interface:
+			ext := ext.(*Interface)
+			fields, err := mergeFields(t.Name, t.Fields, ext.Fields)
+			if err != nil {
+				return err
+			}
+			t.Fields = fields
+			t.Directives = append(t.Directives, ext.Directives...)
+		case *InputObject:
+			for _, v := range ext.(*InputObject).Values {
+				if t.Values.Get(v.Name.Name) != nil {
+					return errors.Errorf("field %q is already declared on %q", v.Name.Name, t.Name)
+				}
+				t.Values = append(t.Values, v)
+			}
+		case *Enum:
+			for _, v := range ext.(*Enum).Values {
+				for _, existing := range t.Values {
+					if existing.Name == v.Name {
+						return errors.Errorf("value %q is already declared on %q", v.Name, t.Name)
+					}
+				}
+				t.Values = append(t.Values, v)
+			}
+		}
+	}
+	s.extensions = nil
+	return nil
+}
+
+func mergeFields(typeName string, fields FieldList, ext FieldList) (FieldList, error) {
+	for _, f := range ext {
+		if fields.Get(f.Name) != nil {
+			return nil, errors.Errorf("field %q is already declared on %q", f.Name, typeName)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
 func resolveNamedType(s *Schema, t NamedType) error {
 	switch t := t.(type) {
 	case *Object:
@@ -271,12 +356,18 @@ func resolveNamedType(s *Schema, t NamedType) error {
 				return err
 			}
 		}
+		if err := resolveDirectives(s, t.Directives); err != nil {
+			return err
+		}
 	case *Interface:
 		for _, f := range t.Fields {
 			if err := resolveField(s, f); err != nil {
 				return err
 			}
 		}
+		if err := resolveDirectives(s, t.Directives); err != nil {
+			return err
+		}
 	case *InputObject:
 		if err := resolveInputObject(s, t.Values); err != nil {
 			return err
@@ -372,24 +463,58 @@ func parseSchema(s *Schema, l *common.Lexer) {
 			directive := parseDirectiveDecl(l)
 			directive.Desc = desc
 			s.Directives[directive.Name] = directive
+		case "extend":
+			s.extensions = append(s.extensions, parseExtension(l, desc))
 		default:
-			l.SyntaxError(fmt.Sprintf(`unexpected %q, expecting "schema", "type", "enum", "interface", "union", "input", "scalar" or "directive"`, x))
+			l.SyntaxError(fmt.Sprintf(`unexpected %q, expecting "schema", "type", "enum", "interface", "union", "input", "scalar", "directive" or "extend"`, x))
 		}
 	}
 }
 
+func parseExtension(l *common.Lexer, desc string) NamedType {
+	switch x := l.ConsumeIdent(); x {
+	case "type":
+		obj := parseObjectDecl(l)
+		obj.Desc = desc
+		return obj
+	case "interface":
+		intf := parseInterfaceDecl(l)
+		intf.Desc = desc
+		return intf
+	case "input":
+		input := parseInputDecl(l)
+		input.Desc = desc
+		return input
+	case "enum":
+		enum := parseEnumDecl(l)
+		enum.Desc = desc
+		return enum
+	default:
+		l.SyntaxError(fmt.Sprintf(`unexpected %q, expecting "type", "interface", "input" or "enum"`, x))
+		return nil
+	}
+}
+
 func parseObjectDecl(l *common.Lexer) *Object {
 	o := &Object{}
 	o.Name = l.ConsumeIdent()
 	if l.Peek() == scanner.Ident {
 		l.ConsumeKeyword("implements")
+		if l.Peek() == '&' {
+			l.ConsumeToken('&')
+		}
 		for {
 			o.interfaceNames = append(o.interfaceNames, l.ConsumeIdent())
-			if l.Peek() == '{' {
+			if l.Peek() == '&' {
+				l.ConsumeToken('&')
+				continue
+			}
+			if l.Peek() == '{' || l.Peek() == '@' {
 				break
 			}
 		}
 	}
+	o.Directives = common.ParseDirectives(l)
 	l.ConsumeToken('{')
 	o.Fields = parseFields(l)
 	l.ConsumeToken('}')
@@ -399,6 +524,7 @@ func parseObjectDecl(l *common.Lexer) *Object {
 func parseInterfaceDecl(l *common.Lexer) *Interface {
 	i := &Interface{}
 	i.Name = l.ConsumeIdent()
+	i.Directives = common.ParseDirectives(l)
 	l.ConsumeToken('{')
 	i.Fields = parseFields(l)
 	l.ConsumeToken('}')
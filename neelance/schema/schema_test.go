@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const descriptionSchema = `
+	"""
+	The root query
+
+	  with an indented line
+	"""
+	type Query {
+		"all of the todos"
+		todos: [Todo!]!
+	}
+
+	# A todo with a comment description
+	type Todo {
+		"""
+		Block strings may contain \""" and "quotes"
+		"""
+		text: String!
+		"""The state of the todo"""
+		state(
+			"strings also describe arguments"
+			verbose: Boolean = false
+		): State!
+	}
+
+	"Filters for todos"
+	input TodoFilter {
+		"""Only find todos containing this text"""
+		text: String = """  plain """
+	}
+
+	enum State {
+		"not done yet"
+		OPEN
+		"""
+		all done
+		"""
+		DONE
+	}
+`
+
+func TestDescriptions(t *testing.T) {
+	s := MustParse(descriptionSchema)
+
+	require.Equal(t, "The root query\n\n  with an indented line", s.Types["Query"].Description())
+	require.Equal(t, "A todo with a comment description", s.Types["Todo"].Description())
+	require.Equal(t, "Filters for todos", s.Types["TodoFilter"].Description())
+
+	todo := s.Types["Todo"].(*Object)
+	require.Equal(t, `Block strings may contain """ and "quotes"`, todo.Fields.Get("text").Desc)
+	require.Equal(t, "The state of the todo", todo.Fields.Get("state").Desc)
+	require.Equal(t, "strings also describe arguments", todo.Fields.Get("state").Args.Get("verbose").Desc)
+
+	filter := s.Types["TodoFilter"].(*InputObject)
+	require.Equal(t, "Only find todos containing this text", filter.Values.Get("text").Desc)
+	require.Equal(t, "  plain ", filter.Values.Get("text").Default.Value(nil))
+
+	state := s.Types["State"].(*Enum)
+	require.Equal(t, "not done yet", state.Values[0].Desc)
+	require.Equal(t, "all done", state.Values[1].Desc)
+
+	t.Run("unterminated block strings are syntax errors", func(t *testing.T) {
+		err := New().Parse(`
+			"""
+			never closed
+			type Query { a: String }
+		`)
+		require.EqualError(t, err, "graphql: syntax error: unterminated block string (line 2, column 4)")
+	})
+
+	t.Run("strings use graphql escapes", func(t *testing.T) {
+		s := MustParse(`
+			"a\/b \"c\" \\ \b\f\n\r\t é 😀"
+			type Query { a(b: String = "\/✓"): String }
+		`)
+		require.Equal(t, "a/b \"c\" \\ \b\f\n\r\t é 😀", s.Types["Query"].Description())
+		require.Equal(t, "/✓", s.Types["Query"].(*Object).Fields.Get("a").Args.Get("b").Default.Value(nil))
+	})
+
+	t.Run("go escapes are syntax errors", func(t *testing.T) {
+		err := New().Parse(`
+			"\x41"
+			type Query { a: String }
+		`)
+		require.EqualError(t, err, `graphql: syntax error: invalid description "\x41": invalid escape \x in string literal (line 2, column 4)`)
+
+		err = New().Parse(`type Query { a(b: String = "\a"): String }`)
+		require.EqualError(t, err, `graphql: syntax error: invalid string "\a": invalid escape \a in string literal (line 1, column 28)`)
+	})
+}
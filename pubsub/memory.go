@@ -0,0 +1,113 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory is a Broker that delivers messages within a single process.
+type Memory struct {
+	mu     sync.Mutex
+	topics map[string]map[*subscriber]struct{}
+	buffer int
+}
+
+var _ Broker = &Memory{}
+
+// NewMemory creates an in-memory broker. buffer sets how many messages may be queued for each subscriber before
+// Publish waits for it to catch up.
+func NewMemory(buffer int) *Memory {
+	return &Memory{
+		topics: map[string]map[*subscriber]struct{}{},
+		buffer: buffer,
+	}
+}
+
+type subscriber struct {
+	mu     sync.RWMutex
+	ch     chan interface{}
+	done   <-chan struct{}
+	closed bool
+}
+
+// send delivers msg unless the subscriber goes away first
+func (s *subscriber) send(ctx context.Context, msg interface{}) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+
+	select {
+	case s.ch <- msg:
+		return nil
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	close(s.ch)
+}
+
+// Publish sends msg to every subscriber of topic. Subscribers that go away while it is being sent are skipped.
+func (m *Memory) Publish(ctx context.Context, topic string, msg interface{}) error {
+	m.mu.Lock()
+	subscribers := make([]*subscriber, 0, len(m.topics[topic]))
+	for s := range m.topics[topic] {
+		subscribers = append(subscribers, s)
+	}
+	m.mu.Unlock()
+
+	for _, s := range subscribers {
+		if err := s.send(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe receives messages published to topic until ctx is done.
+func (m *Memory) Subscribe(ctx context.Context, topic string) (<-chan interface{}, error) {
+	s := &subscriber{
+		ch:   make(chan interface{}, m.buffer),
+		done: ctx.Done(),
+	}
+
+	m.mu.Lock()
+	if m.topics[topic] == nil {
+		m.topics[topic] = map[*subscriber]struct{}{}
+	}
+	m.topics[topic][s] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		delete(m.topics[topic], s)
+		if len(m.topics[topic]) == 0 {
+			delete(m.topics, topic)
+		}
+		m.mu.Unlock()
+
+		s.close()
+	}()
+
+	return s.ch, nil
+}
+
+// Subscribers returns how many subscriptions to topic are currently active.
+func (m *Memory) Subscribers(topic string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.topics[topic])
+}
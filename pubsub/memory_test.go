@@ -0,0 +1,107 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	t.Run("messages are delivered to every subscriber of the topic", func(t *testing.T) {
+		m := NewMemory(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		a, err := m.Subscribe(ctx, "a")
+		require.NoError(t, err)
+		a2, err := m.Subscribe(ctx, "a")
+		require.NoError(t, err)
+		b, err := m.Subscribe(ctx, "b")
+		require.NoError(t, err)
+
+		require.NoError(t, m.Publish(ctx, "a", "hello"))
+		require.Equal(t, "hello", <-a)
+		require.Equal(t, "hello", <-a2)
+
+		select {
+		case msg := <-b:
+			t.Fatalf("unexpected message %v on another topic", msg)
+		default:
+		}
+	})
+
+	t.Run("cancelling a subscription closes its channel", func(t *testing.T) {
+		m := NewMemory(0)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		ch, err := m.Subscribe(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, 1, m.Subscribers("a"))
+
+		cancel()
+		_, ok := <-ch
+		require.False(t, ok)
+		require.Equal(t, 0, m.Subscribers("a"))
+		require.NoError(t, m.Publish(context.Background(), "a", "hello"))
+	})
+
+	t.Run("publish waits for slow subscribers until its context is done", func(t *testing.T) {
+		m := NewMemory(0)
+		_, err := m.Subscribe(context.Background(), "a")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.Equal(t, context.DeadlineExceeded, m.Publish(ctx, "a", "hello"))
+	})
+
+	t.Run("subscribers going away do not block publish", func(t *testing.T) {
+		m := NewMemory(0)
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := m.Subscribe(ctx, "a")
+		require.NoError(t, err)
+
+		published := make(chan error)
+		go func() {
+			published <- m.Publish(context.Background(), "a", "hello")
+		}()
+
+		cancel()
+		require.NoError(t, <-published)
+	})
+}
+
+func TestBind(t *testing.T) {
+	type message struct {
+		Text string
+	}
+
+	t.Run("messages are forwarded to the typed channel", func(t *testing.T) {
+		m := NewMemory(1)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events := make(chan message, 1)
+		require.NoError(t, Bind(ctx, m, "room", events))
+
+		require.NoError(t, m.Publish(ctx, "room", "not a message"))
+		require.NoError(t, m.Publish(ctx, "room", message{Text: "hello"}))
+		require.Equal(t, message{Text: "hello"}, <-events)
+
+		cancel()
+		_, ok := <-events
+		require.False(t, ok)
+	})
+
+	t.Run("only sendable channels can be bound", func(t *testing.T) {
+		m := NewMemory(1)
+
+		err := Bind(context.Background(), m, "room", make(<-chan message))
+		require.EqualError(t, err, "pubsub: cannot bind topic room to <-chan pubsub.message, a sendable channel is required")
+
+		err = Bind(context.Background(), m, "room", message{})
+		require.EqualError(t, err, "pubsub: cannot bind topic room to pubsub.message, a sendable channel is required")
+		require.Equal(t, 0, m.Subscribers("room"))
+	})
+}
@@ -0,0 +1,63 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Broker delivers messages published to a topic to everyone currently subscribed to it.
+type Broker interface {
+	// Publish sends msg to every subscriber of topic, returning once they have all received it or ctx is done.
+	Publish(ctx context.Context, topic string, msg interface{}) error
+
+	// Subscribe returns a channel that receives every message published to topic until ctx is done, after which the
+	// channel is closed.
+	Subscribe(ctx context.Context, topic string) (<-chan interface{}, error)
+}
+
+// Bind subscribes to topic and forwards each message to ch, so a subscription resolver can return a typed channel
+// without running its own goroutine:
+//
+//	events := make(chan Message, 1)
+//	if err := pubsub.Bind(ctx, r.broker, roomName, events); err != nil {
+//		return nil, err
+//	}
+//	return events, nil
+//
+// ch must be a channel that can be sent to. Messages that are not assignable to its element type are dropped, and ch
+// is closed once the subscription ends.
+func Bind(ctx context.Context, b Broker, topic string, ch interface{}) error {
+	out := reflect.ValueOf(ch)
+	if out.Kind() != reflect.Chan || out.Type().ChanDir()&reflect.SendDir == 0 {
+		return fmt.Errorf("pubsub: cannot bind topic %s to %T, a sendable channel is required", topic, ch)
+	}
+	elem := out.Type().Elem()
+
+	messages, err := b.Subscribe(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer out.Close()
+
+		done := reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+		for msg := range messages {
+			v := reflect.ValueOf(msg)
+			if !v.IsValid() || !v.Type().AssignableTo(elem) {
+				continue
+			}
+
+			chosen, _, _ := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: out, Send: v},
+				done,
+			})
+			if chosen == 1 {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
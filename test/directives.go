@@ -0,0 +1,30 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+type roleKey struct{}
+
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
+func HasRole(ctx context.Context, next graphql.Resolver, args map[string]interface{}) (interface{}, error) {
+	if role, _ := ctx.Value(roleKey{}).(string); role != args["role"] {
+		return nil, fmt.Errorf("access denied, %s role required", args["role"])
+	}
+	return next(ctx)
+}
+
+func Upper(ctx context.Context, next graphql.Resolver, args map[string]interface{}) (interface{}, error) {
+	res, err := next(ctx)
+	if s, ok := res.(string); ok {
+		return strings.ToUpper(s), err
+	}
+	return res, err
+}
@@ -8,6 +8,7 @@ import (
 	remote_api "remote_api"
 	strconv "strconv"
 
+	complexity "github.com/vektah/gqlgen/complexity"
 	graphql "github.com/vektah/gqlgen/graphql"
 	introspection "github.com/vektah/gqlgen/neelance/introspection"
 	query "github.com/vektah/gqlgen/neelance/query"
@@ -29,6 +30,7 @@ type Resolvers interface {
 	Element_child(ctx context.Context, obj *models.Element) (models.Element, error)
 	Element_error(ctx context.Context, obj *models.Element) (bool, error)
 	Element_mismatched(ctx context.Context, obj *models.Element) ([]bool, error)
+	Mutation_upload(ctx context.Context, file graphql.Upload) (string, error)
 	Query_path(ctx context.Context) ([]*models.Element, error)
 	Query_date(ctx context.Context, filter models.DateFilter) (bool, error)
 	Query_viewer(ctx context.Context) (*models.Viewer, error)
@@ -39,6 +41,7 @@ type Resolvers interface {
 
 type ResolverRoot interface {
 	Element() ElementResolver
+	Mutation() MutationResolver
 	Query() QueryResolver
 	User() UserResolver
 }
@@ -47,6 +50,9 @@ type ElementResolver interface {
 	Error(ctx context.Context, obj *models.Element) (bool, error)
 	Mismatched(ctx context.Context, obj *models.Element) ([]bool, error)
 }
+type MutationResolver interface {
+	Upload(ctx context.Context, file graphql.Upload) (string, error)
+}
 type QueryResolver interface {
 	Path(ctx context.Context) ([]*models.Element, error)
 	Date(ctx context.Context, filter models.DateFilter) (bool, error)
@@ -73,6 +79,10 @@ func (s shortMapper) Element_mismatched(ctx context.Context, obj *models.Element
 	return s.r.Element().Mismatched(ctx, obj)
 }
 
+func (s shortMapper) Mutation_upload(ctx context.Context, file graphql.Upload) (string, error) {
+	return s.r.Mutation().Upload(ctx, file)
+}
+
 func (s shortMapper) Query_path(ctx context.Context) ([]*models.Element, error) {
 	return s.r.Query().Path(ctx)
 }
@@ -101,6 +111,16 @@ func (e *executableSchema) Schema() *schema.Schema {
 	return parsedSchema
 }
 
+func (e *executableSchema) Complexity(typeName string, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	switch typeName + "." + fieldName {
+	case "Element.child":
+		return complexity.Field(2, childComplexity, args), true
+	case "Query.path":
+		return complexity.Field(5, childComplexity, args), true
+	}
+	return 0, false
+}
+
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
 	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
 
@@ -113,12 +133,24 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 
 	return &graphql.Response{
 		Data:   buf,
-		Errors: ec.Errors,
+		Errors: ec.GetErrors(),
 	}
 }
 
 func (e *executableSchema) Mutation(ctx context.Context, op *query.Operation) *graphql.Response {
-	return graphql.ErrorResponse(ctx, "mutations are not supported")
+	ec := executionContext{graphql.GetRequestContext(ctx), e.resolvers}
+
+	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+		data := ec._Mutation(ctx, op.Selections)
+		var buf bytes.Buffer
+		data.MarshalGQL(&buf)
+		return buf.Bytes()
+	})
+
+	return &graphql.Response{
+		Data:   buf,
+		Errors: ec.GetErrors(),
+	}
 }
 
 func (e *executableSchema) Subscription(ctx context.Context, op *query.Operation) func() *graphql.Response {
@@ -155,7 +187,7 @@ func (ec *executionContext) _Element(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Element_child(ctx context.Context, field graphql.CollectedField, obj *models.Element) graphql.Marshaler {
@@ -257,6 +289,65 @@ func (ec *executionContext) _Element_mismatched(ctx context.Context, field graph
 	})
 }
 
+var mutationImplementors = []string{"Mutation"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Mutation(ctx context.Context, sel []query.Selection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.Doc, sel, mutationImplementors, ec.Variables)
+
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Mutation",
+	})
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Mutation")
+		case "upload":
+			out.Values[i] = ec._Mutation_upload(ctx, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return ec.IncrementalFields(ctx, fields, out)
+}
+
+func (ec *executionContext) _Mutation_upload(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	args := map[string]interface{}{}
+	var arg0 graphql.Upload
+	if tmp, ok := field.Args["file"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalUpload(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["file"] = arg0
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation_upload(ctx, args["file"].(graphql.Upload))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	return graphql.MarshalString(res)
+}
+
 var queryImplementors = []string{"Query"}
 
 // nolint: gocyclo, errcheck, gas, goconst
@@ -291,7 +382,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Query_path(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
@@ -501,7 +592,7 @@ func (ec *executionContext) _User(ctx context.Context, sel []query.Selection, ob
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _User_name(ctx context.Context, field graphql.CollectedField, obj *remote_api.User) graphql.Marshaler {
@@ -511,7 +602,19 @@ func (ec *executionContext) _User_name(ctx context.Context, field graphql.Collec
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
-	res := obj.Name
+	resTmp, err := func(ctx context.Context) (interface{}, error) {
+		return Upper(ctx, func(ctx context.Context) (interface{}, error) {
+			return obj.Name, nil
+		}, map[string]interface{}{})
+	}(ctx)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
 	return graphql.MarshalString(res)
 }
 
@@ -531,7 +634,9 @@ func (ec *executionContext) _User_likes(ctx context.Context, field graphql.Colle
 		}()
 
 		resTmp, err := ec.ResolverMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-			return ec.resolvers.User_likes(ctx, obj)
+			return HasRole(ctx, func(ctx context.Context) (interface{}, error) {
+				return ec.resolvers.User_likes(ctx, obj)
+			}, map[string]interface{}{"role": "ADMIN"})
 		})
 		if err != nil {
 			ec.Error(ctx, err)
@@ -574,7 +679,7 @@ func (ec *executionContext) _Viewer(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) _Viewer_user(ctx context.Context, field graphql.CollectedField, obj *models.Viewer) graphql.Marshaler {
@@ -617,7 +722,7 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) graphql.Marshaler {
@@ -711,7 +816,7 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel []query.Select
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) graphql.Marshaler {
@@ -794,7 +899,7 @@ func (ec *executionContext) ___Field(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) graphql.Marshaler {
@@ -904,7 +1009,7 @@ func (ec *executionContext) ___InputValue(ctx context.Context, sel []query.Selec
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) graphql.Marshaler {
@@ -985,7 +1090,7 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel []query.Selection
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) graphql.Marshaler {
@@ -1103,7 +1208,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel []query.Selection,
 		}
 	}
 
-	return out
+	return ec.IncrementalFields(ctx, fields, out)
 }
 
 func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) graphql.Marshaler {
@@ -1364,15 +1469,20 @@ input DateFilter {
     op: DATE_FILTER_OP = EQ
 }
 
+directive @hasRole(role: String!) on FIELD_DEFINITION
+directive @upper on FIELD_DEFINITION
+
 type User {
-    name: String!
-    likes: [String!]!
+    name: String! @upper
+    likes: [String!]! @hasRole(role: "ADMIN")
 }
 
 type Viewer {
     user: User
 }
 
+scalar Upload
+
 type Query {
     path: [Element]
     date(filter: DateFilter!): Boolean!
@@ -1380,5 +1490,9 @@ type Query {
     jsonEncoding: String!
 }
 
+type Mutation {
+    upload(file: Upload!): String!
+}
+
 // this is a comment with a ` + "`" + `backtick` + "`" + `
 `)
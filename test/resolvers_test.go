@@ -3,9 +3,14 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -108,18 +113,166 @@ func TestJsonEncoding(t *testing.T) {
 	require.Equal(t, "\U000fe4ed", resp.JsonEncoding)
 }
 
+func TestDirectives(t *testing.T) {
+	var role string
+	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(&testResolvers{}),
+		handler.RequestMiddleware(func(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+			return next(WithRole(ctx, role))
+		}),
+	))
+	c := client.New(srv.URL)
+
+	t.Run("field directive can transform the result", func(t *testing.T) {
+		var resp struct {
+			Viewer struct{ User struct{ Name string } }
+		}
+
+		err := c.Post(`{ viewer { user { name } } }`, &resp)
+		require.NoError(t, err)
+		require.Equal(t, "BOB", resp.Viewer.User.Name)
+	})
+
+	t.Run("resolver directive can deny access", func(t *testing.T) {
+		role = "USER"
+		var resp struct{}
+
+		err := c.Post(`{ viewer { user { likes } } }`, &resp)
+		require.EqualError(t, err, `[{"message":"access denied, ADMIN role required","path":["viewer","user","likes"]}]`)
+	})
+
+	t.Run("resolver directive can allow access", func(t *testing.T) {
+		role = "ADMIN"
+		var resp struct {
+			Viewer struct{ User struct{ Likes []string } }
+		}
+
+		err := c.Post(`{ viewer { user { likes } } }`, &resp)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Alpha", "Omega"}, resp.Viewer.User.Likes)
+	})
+}
+
+func TestComplexity(t *testing.T) {
+	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(&testResolvers{}), handler.ComplexityLimit(7)))
+	c := client.New(srv.URL)
+
+	t.Run("configured field costs are used", func(t *testing.T) {
+		var resp struct{}
+		err := c.Post(`{ path { cc:child { error } } }`, &resp)
+
+		require.EqualError(t, err, `http 422: {"data":null,"errors":[{"message":"operation has complexity 8, which exceeds the limit of 7"}]}`)
+	})
+
+	t.Run("below the limit", func(t *testing.T) {
+		var resp struct{ JsonEncoding string }
+		err := c.Post(`{ jsonEncoding }`, &resp)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestFileUpload(t *testing.T) {
+	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(&testResolvers{})))
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	require.NoError(t, mw.WriteField("operations", `{"query":"mutation($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`))
+	require.NoError(t, mw.WriteField("map", `{"0":["variables.file"]}`))
+	w, err := mw.CreateFormFile("0", "hello.txt")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello world"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	resp, err := http.Post(srv.URL, mw.FormDataContentType(), body)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `{"data":{"upload":"hello.txt (11 bytes): hello world"}}`, string(b))
+}
+
+func TestIncrementalDelivery(t *testing.T) {
+	resolvers := &testResolvers{}
+	srv := httptest.NewServer(handler.GraphQL(MakeExecutableSchema(resolvers)))
+
+	post := func(query string) (string, string) {
+		req, err := http.NewRequest("POST", srv.URL, strings.NewReader(fmt.Sprintf(`{"query":%q}`, query)))
+		require.NoError(t, err)
+		req.Header.Set("Accept", "multipart/mixed, application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return resp.Header.Get("Content-Type"), string(b)
+	}
+
+	part := "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"
+
+	t.Run("defer", func(t *testing.T) {
+		contentType, body := post(`{ viewer { user { name } } ... on Query @defer(label: "path") { path { cc: child { error } } } }`)
+
+		require.Equal(t, `multipart/mixed; boundary="-"`, contentType)
+		require.Equal(t, part+`{"data":{"viewer":{"user":{"name":"BOB"}}},"hasNext":true}`+
+			part+`{"data":{"path":[{"cc":{"error":false}},{"cc":{"error":false}},{"cc":{"error":false}},{"cc":{"error":false}}]},"path":[],"label":"path","hasNext":false}`+
+			"\r\n-----\r\n", body)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		_, body := post(`{ path @stream(initialCount: 2) { cc: child { error } } }`)
+
+		require.Equal(t, part+`{"data":{"path":[{"cc":{"error":false}},{"cc":{"error":false}}]},"hasNext":true}`+
+			part+`{"data":{"cc":{"error":false}},"path":["path",2],"hasNext":true}`+
+			part+`{"data":{"cc":{"error":false}},"path":["path",3],"hasNext":false}`+
+			"\r\n-----\r\n", body)
+	})
+
+	t.Run("deferred errors", func(t *testing.T) {
+		// the deferred field fails while the rest of the query is still resolving
+		resolvers.queryDate = func(ctx context.Context, filter models.DateFilter) (bool, error) {
+			return false, fmt.Errorf("boom")
+		}
+		_, body := post(`{ path { cc: child { error } } ... on Query @defer(label: "date") { date(filter: {value: "x"}) } }`)
+
+		require.Equal(t, part+`{"data":{"path":[{"cc":{"error":false}},{"cc":{"error":false}},{"cc":{"error":false}},{"cc":{"error":false}}]},"hasNext":true}`+
+			part+`{"data":{"date":null},"path":[],"label":"date","errors":[{"message":"boom","path":["date"]}],"hasNext":false}`+
+			"\r\n-----\r\n", body)
+	})
+
+	t.Run("nothing deferred", func(t *testing.T) {
+		contentType, body := post(`{ viewer { user { name } } }`)
+
+		require.Equal(t, "application/json", contentType)
+		require.Equal(t, `{"data":{"viewer":{"user":{"name":"BOB"}}}}`, body)
+	})
+}
+
 type testResolvers struct {
 	err       error
 	queryDate func(ctx context.Context, filter models.DateFilter) (bool, error)
 }
 
+func (r *testResolvers) Mutation_upload(ctx context.Context, file graphql.Upload) (string, error) {
+	content, err := ioutil.ReadAll(file.File)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s (%d bytes): %s", file.Filename, file.Size, content), nil
+}
+
 func (r *testResolvers) Query_jsonEncoding(ctx context.Context) (string, error) {
 	return "\U000fe4ed", nil
 }
 
 func (r *testResolvers) Query_viewer(ctx context.Context) (*models.Viewer, error) {
 	return &models.Viewer{
-		User: &remote_api.User{Name: "Bob"},
+		User: &remote_api.User{Name: "Bob", Likes: []string{"Alpha", "Omega"}},
 	}, nil
 }
 